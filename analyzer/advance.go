@@ -0,0 +1,62 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// advanceFixes returns the fixes of the j-th pair of assignStmt, a head advance `q = q[i:]` of target past the
+// index low, whose elements are of type elemType; none if the target cannot be repeated (fixable is false).
+//
+// The first fix inserts clear(q[:i]) before the advance, which needs Go 1.21 and evaluates i a second time, so i
+// must be pure. A single pop, q = q[1:], gets q[0] = nil (or the zero value of elemType) instead, on any Go version.
+// The second fix replaces the advance with slices.Delete(q, 0, i), which evaluates i once but moves the remaining
+// elements to the start of the backing array. Their indexes change, and so does the capacity, so it is classified
+// for verification.
+func (c *checker) advanceFixes(assignStmt *ast.AssignStmt, j int, target, low ast.Expr, elemType types.Type, fixable bool) []analysis.SuggestedFix {
+	if !fixable {
+		return nil
+	}
+	info := c.pass.TypesInfo
+	pos := assignStmt.Pos()
+	name := c.render(target)
+	elem := name
+	if _, isStar := target.(*ast.StarExpr); isStar {
+		elem = "(" + name + ")"
+	}
+
+	var fixes []analysis.SuggestedFix
+	if len(assignStmt.Lhs) == 1 && assignStmt == c.listStmt {
+		var text, message string
+		var imports []analysis.TextEdit
+		if tv, ok := info.Types[low]; ok && tv.Value != nil && constant.Compare(constant.ToInt(tv.Value), token.EQL, constant.MakeInt64(1)) {
+			if zero, edits, ok := c.zeroLiteral(pos, elemType); ok {
+				text, message, imports = elem+"[0] = "+zero, "Zero the consumed element before advancing.", edits
+			}
+		} else if isPure(info, low) && c.goVersionAtLeast(pos, "go1.21") {
+			text, message = "clear("+elem+"[:"+c.sourceOf(low)+"])", "Clear the consumed elements before advancing."
+		}
+		if text != "" {
+			fixes = append(fixes, classify(c.safetyOf(pos, target), analysis.SuggestedFix{
+				Message:   message,
+				TextEdits: append([]analysis.TextEdit{{Pos: pos, End: pos, NewText: []byte(text + "\n" + c.indentAt(pos))}}, imports...),
+			}))
+		}
+	}
+
+	if pkg, imports, ok := c.slicesName(pos); ok && c.deleteClears(pos) {
+		rhs := assignStmt.Rhs[j]
+		replacement := pkg + ".Delete(" + name + ", 0, " + c.sourceOf(low) + ")"
+		fixes = append(fixes, classify(fixVerify, analysis.SuggestedFix{
+			Message: "Replace with slices.Delete, which moves the remaining elements to index 0.",
+			TextEdits: append([]analysis.TextEdit{
+				{Pos: rhs.Pos(), End: rhs.End(), NewText: []byte(keepExprComments(c.pass, rhs.Pos(), rhs.End(), replacement))},
+			}, imports...),
+		}))
+	}
+	return fixes
+}
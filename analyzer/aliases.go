@@ -0,0 +1,251 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// findPointerAliases returns the local pointer variables that are simple aliases of a slice, like p in
+//
+//	p := &state.queue
+//	*p = (*p)[:0]
+//
+// mapped to the slice they point to. A variable qualifies only if its single definition is the address of
+// a slice-typed identifier or field selector, and every other use of it is a dereference. Reassigning the
+// pointer, passing it anywhere, or capturing it in a closure disqualifies it, since the alias could then escape.
+func findPointerAliases(pass *analysis.Pass, inspect *inspector.Inspector) map[*types.Var]ast.Expr {
+	info := pass.TypesInfo
+
+	targets := make(map[*types.Var]ast.Expr)
+	derefs := make(map[*types.Var]int)
+	disqualified := make(map[*types.Var]bool)
+
+	// define records `p := &x` and `var p = &x`.
+	define := func(name *ast.Ident, value ast.Expr) {
+		v, ok := info.Defs[name].(*types.Var)
+		if !ok || v.Parent() == pass.Pkg.Scope() {
+			return
+		}
+		addr, ok := ast.Unparen(value).(*ast.UnaryExpr)
+		if !ok || addr.Op != token.AND {
+			return
+		}
+		if _, ok := selectorName(addr.X); !ok {
+			return
+		}
+		if _, isSlice := info.TypeOf(addr.X).Underlying().(*types.Slice); !isSlice {
+			return
+		}
+		targets[v] = ast.Unparen(addr.X)
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.StarExpr)(nil),
+		(*ast.FuncLit)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE && len(n.Lhs) == len(n.Rhs) {
+				for j, lhs := range n.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						define(ident, n.Rhs[j])
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for j, name := range n.Names {
+					define(name, n.Values[j])
+				}
+			}
+		case *ast.StarExpr:
+			if ident, ok := ast.Unparen(n.X).(*ast.Ident); ok {
+				if v, ok := info.Uses[ident].(*types.Var); ok {
+					derefs[v]++
+				}
+			}
+		case *ast.FuncLit:
+			ast.Inspect(n.Body, func(n2 ast.Node) bool {
+				if ident, ok := n2.(*ast.Ident); ok {
+					if v, ok := info.Uses[ident].(*types.Var); ok && (v.Pos() < n.Pos() || v.Pos() >= n.End()) {
+						disqualified[v] = true
+					}
+				}
+				return true
+			})
+		}
+	})
+
+	if len(targets) == 0 {
+		return nil
+	}
+	uses := make(map[*types.Var]int)
+	for _, obj := range info.Uses {
+		if v, ok := obj.(*types.Var); ok && targets[v] != nil {
+			uses[v]++
+		}
+	}
+	for v := range targets {
+		if disqualified[v] || uses[v] != derefs[v] {
+			delete(targets, v)
+		}
+	}
+	return targets
+}
+
+// resolve returns the slice a dereferenced pointer alias refers to (x for `*p` after `p := &x`),
+// or expr itself if it is not such a dereference.
+func (c *checker) resolve(expr ast.Expr) ast.Expr {
+	star, ok := ast.Unparen(expr).(*ast.StarExpr)
+	if !ok {
+		return expr
+	}
+	ident, ok := ast.Unparen(star.X).(*ast.Ident)
+	if !ok {
+		return expr
+	}
+	if v, ok := c.pass.TypesInfo.Uses[ident].(*types.Var); ok {
+		if target, ok := c.ptrAliases[v]; ok {
+			return target
+		}
+	}
+	return expr
+}
+
+// sameSlice reports whether a and b denote the same slice, seeing through pointer aliases.
+func (c *checker) sameSlice(a, b ast.Expr) bool {
+	return identicalExpr(c.pass.TypesInfo, c.resolve(a), c.resolve(b))
+}
+
+// sliceCopy is a plain copy of a slice value, `tmp := s` or `tmp = s`, made directly in the statement list list.
+// Until either side is reassigned, dst and src share a backing array, so clearing one clears the other.
+type sliceCopy struct {
+	stmt     *ast.AssignStmt
+	dst, src ast.Expr
+	list     ast.Node
+}
+
+// findSliceCopies returns the plain copies of slice values into local variables, from identifiers and field selectors.
+// Copies into variables whose address is taken or that are captured by a closure are left out, since the variable
+// could then be reassigned anywhere.
+func findSliceCopies(pass *analysis.Pass, inspect *inspector.Inspector) []sliceCopy {
+	info := pass.TypesInfo
+
+	var copies []sliceCopy
+	disqualified := make(map[*types.Var]bool)
+	for cur := range inspect.Root().Preorder((*ast.AssignStmt)(nil), (*ast.UnaryExpr)(nil), (*ast.FuncLit)(nil)) {
+		switch n := cur.Node().(type) {
+		case *ast.AssignStmt:
+			switch cur.Parent().Node().(type) {
+			case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+			default:
+				continue
+			}
+			if len(n.Lhs) != len(n.Rhs) {
+				continue
+			}
+			for j, lhs := range n.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				v, ok := info.ObjectOf(ident).(*types.Var)
+				if !ok || v.Parent() == pass.Pkg.Scope() {
+					continue
+				}
+				if _, isSlice := v.Type().Underlying().(*types.Slice); !isSlice {
+					continue
+				}
+				if _, ok := selectorName(n.Rhs[j]); !ok || identicalExpr(info, lhs, n.Rhs[j]) {
+					continue
+				}
+				copies = append(copies, sliceCopy{stmt: n, dst: lhs, src: ast.Unparen(n.Rhs[j]), list: cur.Parent().Node()})
+			}
+		case *ast.UnaryExpr:
+			if ident, ok := ast.Unparen(n.X).(*ast.Ident); ok && n.Op == token.AND {
+				if v, ok := info.Uses[ident].(*types.Var); ok {
+					disqualified[v] = true
+				}
+			}
+		case *ast.FuncLit:
+			ast.Inspect(n.Body, func(n2 ast.Node) bool {
+				if ident, ok := n2.(*ast.Ident); ok {
+					if v, ok := info.Uses[ident].(*types.Var); ok && (v.Pos() < n.Pos() || v.Pos() >= n.End()) {
+						disqualified[v] = true
+					}
+				}
+				return true
+			})
+		}
+	}
+	return slices.DeleteFunc(copies, func(cp sliceCopy) bool {
+		return disqualified[info.ObjectOf(cp.dst.(*ast.Ident)).(*types.Var)]
+	})
+}
+
+// aliasClearOf returns the argument of the clear in stmt if it is not target itself but shares its backing array
+// through a plain copy, as tmp in `tmp := s; clear(tmp); s = s[:0]` (or the other way around), or nil otherwise.
+// The copy must be made earlier in a statement list enclosing stmt, and neither side may be reassigned in between.
+func (c *checker) aliasClearOf(stmt ast.Stmt, target ast.Expr) ast.Expr {
+	info := c.pass.TypesInfo
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok || c.funcDecl == nil || c.funcDecl.Body == nil {
+		return nil
+	}
+	call, ok := ast.Unparen(exprStmt.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isBuiltinCall(info, call, "clear") {
+		return nil
+	}
+	arg := ast.Unparen(call.Args[0])
+	if full := fullLengthOf(info, arg); full != nil {
+		arg = full
+	} else if full := fullCapacityOf(info, arg); full != nil {
+		arg = full
+	}
+	for _, cp := range c.sliceCopies {
+		if cp.stmt.End() > stmt.Pos() || stmt.Pos() < cp.list.Pos() || stmt.End() > cp.list.End() {
+			continue
+		}
+		if !(c.sameSlice(cp.dst, arg) && c.sameSlice(cp.src, target)) && !(c.sameSlice(cp.dst, target) && c.sameSlice(cp.src, arg)) {
+			continue
+		}
+		if !c.reassignedBetween(cp.stmt.End(), stmt.Pos(), cp.dst, cp.src) {
+			return arg
+		}
+	}
+	return nil
+}
+
+// reassignedBetween reports whether a statement of the enclosing function between from and to assigns to any of exprs.
+func (c *checker) reassignedBetween(from, to token.Pos, exprs ...ast.Expr) bool {
+	reassigned := false
+	ast.Inspect(c.funcDecl.Body, func(n ast.Node) bool {
+		if reassigned || n == nil || n.End() <= from || n.Pos() >= to {
+			return false
+		}
+		var lhs []ast.Expr
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			lhs = n.Lhs
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN {
+				lhs = []ast.Expr{n.Key, n.Value}
+			}
+		}
+		for _, l := range lhs {
+			if l != nil && slices.ContainsFunc(exprs, func(e ast.Expr) bool { return c.sameSlice(e, l) }) {
+				reassigned = true
+			}
+		}
+		return !reassigned
+	})
+	return reassigned
+}
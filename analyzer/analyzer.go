@@ -1,10 +1,22 @@
 package clearslice
 
 import (
+	"bytes"
+	"fmt"
 	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/token"
 	"go/types"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 )
@@ -12,29 +24,376 @@ import (
 // Doc is the documentation for the clearslice linter.
 const Doc = `clearslice detects when slices of non-primitive types are resized to zero length without explicitly clearing elements.
 This helps prevent unintended liveness of objects in the underlying array, which can delay garbage collection.
-It recommends using slices.Delete to clear elements up to the full capacity when resetting the length to zero.
-It now avoids false positives when clear() is called immediately before resizing to zero.`
+It also reports related shapes that leave elements behind, such as pops, swap removals and splices, and with flags
+partial truncations, head advances and more. It does not report slices that are already cleared or never used again.
+Fixes use slices.Delete or clear, as the Go version of the file allows.`
 
-var analyzer = &analysis.Analyzer{
-	Name:     "clearslice",
-	Doc:      Doc,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
-	Run:      run,
+// Diagnostic categories, which drivers can use to route findings of different kinds.
+const (
+	// categoryTruncation is the category of the main check.
+	categoryTruncation = "truncation"
+	// categoryReusePoint is the category of main check findings inside reuse methods such as Reset.
+	categoryReusePoint = "reuse-point"
+	// categoryAppendReuse is the category of refills with append(s[:0], ...), which are reported separately
+	// from truncations (also inside reuse methods) since the idiom is usually intentional.
+	categoryAppendReuse = "append-reuse"
+	// categoryDiscardedDelete is the category of slices.Delete calls whose result is discarded.
+	categoryDiscardedDelete = "discarded-delete"
+	// categoryIneffectiveClear is the category of clear calls that run after the truncation they were meant to precede.
+	categoryIneffectiveClear = "ineffective-clear"
+	// categoryRangeCopy is the category of truncations of range value variables, which do not affect the collection.
+	categoryRangeCopy = "range-copy"
+	// categoryParamCopy is the category of truncations of by-value parameters whose new value is never used.
+	categoryParamCopy = "param-copy"
+	// categoryReceiverCopy is the category of truncations of fields of value receivers whose new value is never used.
+	categoryReceiverCopy = "receiver-copy"
+	// categoryGetterCopy is the category of truncations of locals holding a returned slice whose new value is never used.
+	categoryGetterCopy = "getter-copy"
+	// categoryPoolPut is the category of slices put into a sync.Pool without clearing their elements.
+	categoryPoolPut = "pool-put"
+	// categorySubsliceRetention is the category of subslices of large slices stored in long-lived places.
+	categorySubsliceRetention = "subslice-retention"
+	// categoryRedundantClear is the category of clears of slices whose elements hold no references.
+	categoryRedundantClear = "redundant-clear"
+	// categoryDeleteBounds is the category of slices.Delete calls whose end index can exceed the length of the slice.
+	categoryDeleteBounds = "delete-bounds"
+	// categoryRealloc is the category of slice fields reset to nil or an empty literal where they are reused.
+	categoryRealloc = "realloc"
+	// categoryCopyTail is the category of copies into reused buffers that leave the old tail reachable.
+	categoryCopyTail = "copy-tail"
+	// categoryReextension is the category of reslices that lengthen a slice again after a reported truncation.
+	categoryReextension = "re-extension"
+	// categoryAppendAlias is the category of appends into the zero-length view of a slice that is still used.
+	categoryAppendAlias = "append-alias"
+	// categoryModernizeClear is the category of element-zeroing loops that can be replaced with clear.
+	categoryModernizeClear = "modernize-clear"
+	// categoryResetMake is the category of slice fields reallocated with their own capacity in reuse methods.
+	categoryResetMake = "reset-make"
+	// categoryElemAddr is the category of element pointers and single-element subslices that pin large arrays.
+	categoryElemAddr = "elem-addr"
+	// categorySecret is the category of truncations of byte slices named like secrets, reported in secrets mode.
+	categorySecret = "secret"
+	// categoryHeapPop is the category of heap.Interface Pop methods that do not zero the popped slot.
+	categoryHeapPop = "heap-pop"
+	// categoryRingSlot is the category of ring buffer methods that advance past a read slot without clearing it.
+	categoryRingSlot = "ring-slot"
+	// categoryAliasing is the category of zero-length views of another slice, reported with -report-aliasing-decls.
+	categoryAliasing = "aliasing"
+)
+
+// config holds the settings of one analyzer instance, populated from its flags.
+type config struct {
+	// reportAliasingDecls enables reporting `t := s[:0]` where t is a different variable than s.
+	reportAliasingDecls bool
+	// reportPartial enables reporting truncations to a nonzero length, `s = s[:n]`.
+	reportPartial bool
+	// reportAppendReuse enables reporting refills `s = append(s[:0], xs...)` that may be shorter than before.
+	reportAppendReuse bool
+	// reportSubsliceRetention enables reporting subslices of large local slices stored in long-lived places.
+	reportSubsliceRetention bool
+	// reportAdvance enables reporting head advances of queues, `q = q[i:]`.
+	reportAdvance bool
+	// reportRedundantClear enables reporting full-range slices.Delete calls and clears of slices without references.
+	reportRedundantClear bool
+	// reportRealloc enables reporting slice fields reset to nil or an empty literal inside loops and reuse methods.
+	reportRealloc bool
+	// reportCopyTail enables reporting `n := copy(dst, src)` into reused buffers whose tail dst[n:] is not handled.
+	reportCopyTail bool
+	// reportAppendAlias enables reporting `d := append(s[:0], ...)` while s is still used later.
+	reportAppendAlias bool
+	// modernizeClear enables suggesting clear(s) for loops zeroing every element of s.
+	modernizeClear bool
+	// reportResetMake enables reporting `r.items = make([]T, 0, cap(r.items))` inside reuse methods.
+	reportResetMake bool
+	// reportElemAddr enables reporting element pointers and single-element subslices of large slices that escape.
+	reportElemAddr bool
+	// reportRingSlots enables reporting ring buffer methods that advance an index past a slot they read.
+	reportRingSlots bool
+	// skipGenerated enables dropping the findings in generated files.
+	skipGenerated bool
+	// includeTests enables reporting the findings in _test.go files.
+	includeTests bool
+	// baseline is the path of the file listing the known findings not to report, if any.
+	baseline string
+	// baselineOnce guards loading the baseline, shared by the runs on all packages.
+	baselineOnce    sync.Once
+	baselineEntries map[string][]BaselineEntry
+	baselineErr     error
+	// writeBaseline is the path of the file to write the identities of all the findings to, if any.
+	writeBaseline string
+	// writtenMu guards written, the entries written to writeBaseline so far, by package.
+	writtenMu sync.Mutex
+	written   map[string][]BaselineEntry
+	// requireIgnoreReason enables reporting //clearslice:ignore directives without a reason.
+	requireIgnoreReason bool
+	// assumeMove suppresses truncations of locals whose elements were just appended to another slice.
+	assumeMove bool
+	// secrets enables reporting truncations of []byte and []rune slices whose name matches secretNames.
+	secrets bool
+	// secretNames matches the variable and field names considered to hold secrets.
+	secretNames namePattern
+	// reuseMethods names the methods treated as reuse points of buffer-owning types.
+	reuseMethods nameList
+	// generic controls how slices whose element type is a type parameter are classified.
+	generic genericMode
+	// fixStyle selects the fix of truncations offered first: slices.Delete, or clear before the truncation.
+	fixStyle fixStyle
+	// fixTemplate is the text/template of the statement the fix replaces truncations with instead of slices.Delete,
+	// like {{.Pkg}}.ResetSlice(&{{.Expr}}), if any, and fixImport the import path of the package it calls.
+	fixTemplate string
+	fixImport   string
+	// fixTemplateOnce guards parsing fixTemplate, and fixTemplateWarning reporting that it cannot be used,
+	// both shared by the runs on all packages.
+	fixTemplateOnce    sync.Once
+	fixTemplateParsed  *template.Template
+	fixTemplateErr     error
+	fixTemplateWarning sync.Once
+	// suggestFixes enables attaching suggested fixes to diagnostics; without it, they only carry their message.
+	suggestFixes bool
+	// fixes selects the classes of the fixes attached to diagnostics: all of them, or only those classified safe.
+	fixes fixSet
+	// fixComment is the text of the line comment inserted by fixes above the line they change, if any.
+	fixComment fixComment
+	// excludePackages holds the patterns of the package paths not to analyze, like example.com/app/internal/legacy/...
+	excludePackages nameList
+}
+
+// excludes reports whether the package with the given path matches one of the -exclude-packages patterns.
+// As in the patterns of the go command, "..." matches any string, and a trailing "/..." also matches the
+// path before it, so example.com/app/legacy/... matches example.com/app/legacy itself too.
+func (c *config) excludes(path string) bool {
+	for _, pattern := range c.excludePackages {
+		if matchPackagePattern(pattern, path) {
+			return true
+		}
+	}
+	return false
 }
 
-// NewAnalyzer creates the singleton instance of the clearslice analyzer.
+// matchPackagePattern reports whether path matches pattern in the sense of config.excludes.
+func matchPackagePattern(pattern, path string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\.\.\.`, `.*`)
+	if rest, ok := strings.CutSuffix(expr, `/.*`); ok {
+		expr = rest + `(/.*)?`
+	}
+	matched, _ := regexp.MatchString(`^`+expr+`$`, path)
+	return matched
+}
+
+// genericMode is a flag.Value selecting how type parameters without type terms in their constraint are classified.
+type genericMode string
+
+const (
+	// genericConservative assumes that type parameters constrained only by methods (or by any) may hold references.
+	genericConservative genericMode = "conservative"
+	// genericStrict only reports type parameters whose constraint explicitly admits a reference-bearing type.
+	genericStrict genericMode = "strict"
+)
+
+func (m *genericMode) String() string { return string(*m) }
+
+func (m *genericMode) Set(value string) error {
+	switch genericMode(value) {
+	case genericConservative, genericStrict:
+		*m = genericMode(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid generic mode %q: want %q or %q", value, genericConservative, genericStrict)
+	}
+}
+
+// fixStyle is a flag.Value selecting the preferred rewrite of truncations.
+type fixStyle string
+
+const (
+	// fixStyleDelete replaces the truncation with s = slices.Delete(s, 0, len(s)).
+	fixStyleDelete fixStyle = "delete"
+	// fixStyleClear inserts clear(s) before the truncation, keeping it as written.
+	fixStyleClear fixStyle = "clear"
+)
+
+func (s *fixStyle) String() string { return string(*s) }
+
+func (s *fixStyle) Set(value string) error {
+	switch fixStyle(value) {
+	case fixStyleDelete, fixStyleClear:
+		*s = fixStyle(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid fix style %q: want %q or %q", value, fixStyleDelete, fixStyleClear)
+	}
+}
+
+// nameList is a flag.Value holding a comma-separated list of names.
+type nameList []string
+
+func (l *nameList) String() string { return strings.Join(*l, ",") }
+
+func (l *nameList) Set(value string) error {
+	*l = nil
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			*l = append(*l, name)
+		}
+	}
+	return nil
+}
+
+// checker carries the pass and configuration through a single run of the analyzer.
+type checker struct {
+	pass *analysis.Pass
+	*config
+
+	// zeroVars holds the local variables that provably hold zero wherever they are used.
+	zeroVars map[*types.Var]bool
+	// zeroBuffers maps the slice variables that provably hold only zero values to their length.
+	zeroBuffers map[*types.Var]int64
+	// ptrAliases maps local pointer variables like p in `p := &state.queue` to the slice they point to.
+	ptrAliases map[*types.Var]ast.Expr
+	// helpers maps the clearing helpers of the package to what they clear.
+	helpers map[*types.Func]*ClearsArgs
+	// cfgs holds the control-flow graphs of the functions of the package.
+	cfgs *ctrlflow.CFGs
+	// sliceCopies holds the plain copies of slice values, like `tmp := s`, which alias the backing array of s.
+	sliceCopies []sliceCopy
+	// rangeValues maps the value variables declared by range statements, like v in `for k, v := range m`, to their statement.
+	rangeValues map[*types.Var]*ast.RangeStmt
+	// funcDecl is the function declaration enclosing the statements being checked, if any.
+	funcDecl *ast.FuncDecl
+	// inLoop reports whether the statements being checked run inside a loop of the enclosing function.
+	inLoop bool
+	// listStmt is the statement of the enclosing statement list currently being checked.
+	// Only a truncation that is this statement itself can have code inserted before it.
+	listStmt ast.Stmt
+	// listCursor is the cursor of the statement list (a block or a clause) enclosing listStmt.
+	listCursor inspector.Cursor
+	// handled holds statements already reported (or deliberately skipped) as part of a multi-statement idiom,
+	// so the single-statement checks leave them alone.
+	handled map[ast.Stmt]bool
+	// truncated holds the truncations reported by the main check, in the order they were reported.
+	truncated []truncationSite
+	// addedImports holds the imports added to the files of the package by fixes, so that all the fixes of a file
+	// add them under the same name with the same edits.
+	addedImports map[fileImport]addedImport
+	// expSlices reports whether a file of the package imports golang.org/x/exp/slices.
+	expSlices bool
+}
+
+// NewAnalyzer creates a new instance of the clearslice analyzer with its own flags.
 func NewAnalyzer() *analysis.Analyzer {
-	return &analysis.Analyzer{
-		Name:     analyzer.Name,
-		Doc:      analyzer.Doc,
-		Requires: analyzer.Requires,
-		Run:      run,
+	c := &config{
+		reuseMethods:  nameList{"Reset", "Clear", "Recycle"},
+		skipGenerated: true,
+		includeTests:  true,
+		generic:       genericConservative,
+		fixStyle:      fixStyleDelete,
+		suggestFixes:  true,
+		fixes:         fixSetAll,
+		secretNames:   namePattern{regexp.MustCompile(defaultSecretNames)},
+	}
+	a := &analysis.Analyzer{
+		Name:       "clearslice",
+		Doc:        Doc,
+		Requires:   []*analysis.Analyzer{inspect.Analyzer, ctrlflow.Analyzer},
+		Run:        c.run,
+		ResultType: reflect.TypeOf(new(Result)),
+		FactTypes:  []analysis.Fact{new(ClearsArgs)},
 	}
+	a.Flags.BoolVar(&c.reportAliasingDecls, "report-aliasing-decls", false,
+		"also report assignments like \"t := s[:0]\" that alias the backing array of a different slice s")
+	a.Flags.BoolVar(&c.reportPartial, "report-partial", false,
+		"also report truncations to a nonzero length like \"s = s[:n]\", which keep the elements of s[n:] reachable")
+	a.Flags.BoolVar(&c.reportAppendReuse, "report-append-reuse", false,
+		"also report refills like \"s = append(s[:0], xs...)\" that may leave elements beyond the new length reachable")
+	a.Flags.BoolVar(&c.reportSubsliceRetention, "report-subslice-retention", false,
+		"also report subslices of large local slices (from io.ReadAll, bytes.Split, or a large make) stored in fields, package variables or maps")
+	a.Flags.BoolVar(&c.reportAdvance, "report-advance", false,
+		"also report head advances like \"q = q[i:]\", which keep the consumed elements q[:i] reachable")
+	a.Flags.BoolVar(&c.reportRedundantClear, "report-redundant-clear", false,
+		"also report slices.Delete(s, 0, len(s)) and clear-then-truncate pairs on slices whose elements hold no references, suggesting s = s[:0]")
+	a.Flags.BoolVar(&c.reportRealloc, "report-realloc", false,
+		"also report slice fields reset to nil or an empty literal inside loops and reuse methods, which discards their capacity")
+	a.Flags.BoolVar(&c.reportCopyTail, "report-copy-tail", false,
+		"also report n := copy(dst, src) into reused field or pool buffers when dst[n:] is not cleared, overwritten or truncated afterwards")
+	a.Flags.BoolVar(&c.reportAppendAlias, "report-append-alias", false,
+		"also report d := append(s[:0], ...) for a different variable d while s is still used, which overwrites the elements of s")
+	a.Flags.BoolVar(&c.reportResetMake, "report-reset-make", false,
+		"also report slice fields reallocated with their own capacity, like r.items = make([]T, 0, cap(r.items)), inside reuse methods")
+	a.Flags.BoolVar(&c.reportElemAddr, "report-elem-addr", false,
+		"also report pointers to elements (&s[i]) and single-element subslices of large local slices that are stored or returned")
+	a.Flags.BoolVar(&c.reportRingSlots, "report-ring-slots", false,
+		"also report ring buffer methods that read buf[head] and advance head without zeroing the slot, which stays reachable until the buffer wraps")
+	a.Flags.BoolVar(&c.assumeMove, "assume-move", false,
+		"do not report truncations like \"batch = batch[:0]\" right after \"dst = append(dst, batch...)\" moved all the elements of a local slice elsewhere")
+	a.Flags.BoolVar(&c.modernizeClear, "modernize-clear", false,
+		"also suggest clear(s) for range loops that only zero every element of s (Go 1.21+)")
+	a.Flags.BoolVar(&c.secrets, "secrets", false,
+		"also report zero-length truncations of []byte and []rune slices named like secrets (see -secret-names) without wiping them")
+	a.Flags.BoolVar(&c.skipGenerated, "skip-generated", true,
+		"do not report findings in generated files, marked by a \"// Code generated ... DO NOT EDIT.\" comment")
+	a.Flags.BoolVar(&c.includeTests, "include-tests", true,
+		"report findings in _test.go files, including those of external test packages")
+	a.Flags.StringVar(&c.baseline, "baseline", "",
+		"path of a JSON file listing known findings by their stable identity; they are not reported, only new ones are")
+	a.Flags.StringVar(&c.writeBaseline, "write-baseline", "",
+		"path of a JSON file to write the stable identity of every finding to, including those known from -baseline, for use as a -baseline file")
+	a.Flags.BoolVar(&c.requireIgnoreReason, "require-ignore-reason", false,
+		"report //clearslice:ignore directives without a reason after \"--\"")
+	a.Flags.Var(&c.secretNames, "secret-names",
+		"regular expression matching the variable and field names considered secret by -secrets")
+	a.Flags.Var(&c.reuseMethods, "reuse-methods",
+		"comma-separated names of methods treated as reuse points; findings inside them get the \""+categoryReusePoint+"\" category")
+	a.Flags.Var(&c.excludePackages, "exclude-packages",
+		"comma-separated patterns of package paths not to analyze, where \"...\" matches any string, like example.com/app/internal/legacy/...")
+	a.Flags.Var(&c.generic, "generic",
+		"classification of type parameter elements whose constraint has no type terms (like any): "+
+			"\"conservative\" assumes they may hold references, \"strict\" does not")
+	a.Flags.Var(&c.fixStyle, "fix-style",
+		"fix offered first for truncations, and the only one drivers applying a single fix use: "+
+			"\"delete\" rewrites to slices.Delete, \"clear\" inserts clear(s) before the truncation (Go 1.21+)")
+	a.Flags.StringVar(&c.fixTemplate, "fix-template", "",
+		"Go text/template of the statement replacing truncations instead of slices.Delete, with the fields "+
+			"{{.Expr}} (the slice), {{.Len}} (its length) and {{.Pkg}} (the name of the -fix-import package), like {{.Pkg}}.ResetSlice(&{{.Expr}})")
+	a.Flags.StringVar(&c.fixImport, "fix-import", "",
+		"import path of the package -fix-template calls, added to the files that lack it")
+	a.Flags.BoolVar(&c.suggestFixes, "suggest-fixes", true,
+		"attach suggested fixes to diagnostics; with false, drivers applying fixes, like -fix, leave the code as it is")
+	a.Flags.Var(&c.fixes, "fixes",
+		"fixes attached to diagnostics: \"all\", or \"safe\" for only those whose message starts with [safe], which keep "+
+			"what the code does apart from the elements they clear; the others start with [verify]")
+	a.Flags.Var(&c.fixComment, "fix-comment",
+		"text of a line comment the fixes insert above the line they change, like \"cleared to release references for GC (clearslice)\"; "+
+			"no comment is inserted by default")
+	return a
 }
 
 // run executes the clearslice linter.
-func run(pass *analysis.Pass) (interface{}, error) {
+func (c *config) run(pass *analysis.Pass) (interface{}, error) {
+	if c.excludes(pass.Pkg.Path()) {
+		return new(Result), nil
+	}
+	// The fixes are classified under the suppressions, so that only those of reported findings are recorded.
+	var fixes []ClassifiedFix
+	pass, result, err := withSuppressions(withFixPolicy(pass, c, &fixes), c)
+	if err != nil {
+		return nil, err
+	}
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	chk := &checker{
+		pass:        pass,
+		config:      c,
+		zeroVars:    findZeroVars(pass, inspect),
+		zeroBuffers: findZeroBuffers(pass, inspect),
+		ptrAliases:  findPointerAliases(pass, inspect),
+		sliceCopies: findSliceCopies(pass, inspect),
+		cfgs:        pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs),
+		rangeValues: findRangeValues(pass, inspect),
+		handled:     make(map[ast.Stmt]bool),
+		expSlices:   importsPath(pass, expSlicesPath),
+	}
+
+	chk.findClearingHelpers()
 
 	// We need to inspect BlockStmts (and similar statement lists) to check for sequential statements.
 	nodeFilter := []ast.Node{
@@ -43,9 +402,9 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		(*ast.CommClause)(nil), // For select statements
 	}
 
-	inspect.Preorder(nodeFilter, func(n ast.Node) {
+	for cur := range inspect.Root().Preorder(nodeFilter...) {
 		var stmts []ast.Stmt
-		switch node := n.(type) {
+		switch node := cur.Node().(type) {
 		case *ast.BlockStmt:
 			stmts = node.List
 		case *ast.CaseClause:
@@ -53,149 +412,1154 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		case *ast.CommClause:
 			stmts = node.Body
 		default:
-			return
+			continue
 		}
 
-		for i, stmt := range stmts {
-			assignStmt, ok := stmt.(*ast.AssignStmt)
-			if !ok {
-				continue
+		chk.funcDecl = nil
+		for fn := range cur.Enclosing((*ast.FuncDecl)(nil)) {
+			chk.funcDecl = fn.Node().(*ast.FuncDecl)
+			break
+		}
+		chk.inLoop = inLoop(cur)
+
+		for i := range stmts {
+			chk.checkMapValueRoundTrip(stmts, i)
+			chk.checkShiftRemove(stmts, i)
+			chk.checkSwapRemove(stmts, i)
+			chk.checkPopBack(stmts, i)
+			chk.checkClearAfterTruncation(stmts, i)
+			chk.checkClearOfEmpty(stmts, i)
+			chk.checkGetterCopy(stmts, i)
+			if c.reportRedundantClear {
+				chk.checkRedundantClear(stmts, i)
 			}
+			if c.reportCopyTail {
+				chk.checkCopyTail(stmts, i)
+			}
+		}
 
-			// Check for `foo = foo[:0]` or `myObj.sliceField = myObj.sliceField[:0]` patterns.
-			if len(assignStmt.Lhs) != 1 || len(assignStmt.Rhs) != 1 {
+		for i, stmt := range stmts {
+			if chk.handled[stmt] {
 				continue
 			}
-
-			// The LHS can be either an identifier (e.g., `x`) or a selector expression (e.g., `myObj.sliceField`).
-			var lhsExpr ast.Expr
-			var sliceName string // This will store "x" or "myObj.sliceField" as a string for reporting
-
-			switch lhs := assignStmt.Lhs[0].(type) {
-			case *ast.Ident:
-				lhsExpr = lhs
-				sliceName = lhs.Name
-			case *ast.SelectorExpr:
-				lhsExpr = lhs
-				// Reconstruct the full selector expression name for reporting.
-				// This is a simplified reconstruction; for complex cases, pass.Fset.Position(lhs.Pos()).String()
-				// or a more robust AST printer might be needed.
-				if xIdent, ok := lhs.X.(*ast.Ident); ok {
-					sliceName = xIdent.Name + "." + lhs.Sel.Name
-				} else {
-					// If the selector's X is not an ident (e.g., a function call returning a struct),
-					// we might not be able to easily get a string name, so skip for now.
-					continue
-				}
-			default:
-				continue // Not an identifier or selector, not interested.
+			var prevStmt ast.Stmt
+			if i > 0 {
+				prevStmt = stmts[i-1]
 			}
+			chk.listStmt = stmt
+			chk.listCursor = cur
+			chk.checkStmt(stmt, prevStmt)
+		}
+	}
 
-			rhsSliceExpr, ok := assignStmt.Rhs[0].(*ast.SliceExpr)
-			if !ok {
-				continue
+	chk.checkDeleteBounds(inspect)
+	chk.checkHeapPops()
+	chk.checkReextension(inspect)
+	if c.reportSubsliceRetention {
+		chk.checkSubsliceRetention(inspect)
+	}
+	if c.reportElemAddr {
+		chk.checkElementAddresses(inspect)
+	}
+	if c.reportRingSlots {
+		chk.checkRingSlots(inspect)
+	}
+	if c.modernizeClear {
+		chk.checkZeroingLoops(inspect)
+		chk.checkZeroCopies(inspect)
+	}
+
+	result.Fixes = fixes
+	if err := c.recordBaseline(pass.Pkg.Path(), result.Baseline); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkStmt checks stmt, and the init statements nested in its header, for truncations.
+// prevStmt is the statement executed immediately before stmt, or nil if there is none.
+func (c *checker) checkStmt(stmt ast.Stmt, prevStmt ast.Stmt) {
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		// Check for `foo = foo[:0]` or `myObj.sliceField = myObj.sliceField[:0]` patterns.
+		// Tuple assignments such as `a, b = a[:0], b[:0]` are checked pair by pair.
+		if len(stmt.Lhs) != len(stmt.Rhs) {
+			return
+		}
+		for j := range stmt.Lhs {
+			c.checkTruncation(stmt, j, prevStmt)
+			c.checkAppendSplice(stmt, j)
+			if c.reportAppendReuse {
+				c.checkAppendReuse(stmt, j, prevStmt)
+			}
+			if c.reportAliasingDecls {
+				c.checkAliasingDecl(stmt, j)
+			}
+			if c.reportRedundantClear {
+				c.checkRedundantDelete(stmt, j)
+			}
+			if c.reportRealloc {
+				c.checkRealloc(stmt, j)
+			}
+			if c.reportAppendAlias {
+				c.checkAppendAlias(stmt, j)
+			}
+			if c.reportResetMake {
+				c.checkResetMake(stmt, j)
+			}
+		}
+	case *ast.ExprStmt:
+		c.checkDiscardedDelete(stmt)
+		c.checkPoolPut(stmt)
+	case *ast.DeferStmt:
+		c.checkPoolPut(stmt)
+	case *ast.IfStmt:
+		// The init statement of an `if` runs right after the statement preceding it in the enclosing block,
+		// and the init statement of each `else if` runs after the init statements earlier in the chain.
+		for ifStmt := stmt; ifStmt != nil; ifStmt, _ = ifStmt.Else.(*ast.IfStmt) {
+			if ifStmt.Init != nil {
+				c.checkStmt(ifStmt.Init, prevStmt)
+				prevStmt = ifStmt.Init
 			}
+		}
+	case *ast.ForStmt:
+		if stmt.Init != nil {
+			c.checkStmt(stmt.Init, prevStmt)
+		}
+		// The post statement runs after the last statement of the loop body on every iteration.
+		if stmt.Post != nil {
+			var lastStmt ast.Stmt
+			if n := len(stmt.Body.List); n > 0 {
+				lastStmt = stmt.Body.List[n-1]
+			}
+			c.checkStmt(stmt.Post, lastStmt)
+		}
+	case *ast.SwitchStmt:
+		if stmt.Init != nil {
+			c.checkStmt(stmt.Init, prevStmt)
+		}
+	case *ast.TypeSwitchStmt:
+		if stmt.Init != nil {
+			c.checkStmt(stmt.Init, prevStmt)
+		}
+	case *ast.LabeledStmt:
+		// Labels are common on loops and switches; the labeled statement runs in the label's place.
+		c.checkStmt(stmt.Stmt, prevStmt)
+	}
+}
 
-			// Ensure the right-hand side's sliced expression matches the left-hand side.
-			// This requires comparing the AST nodes themselves, not just their string names.
-			if !identicalExpr(lhsExpr, rhsSliceExpr.X) {
-				continue
+// checkTruncation reports the j-th LHS/RHS pair of assignStmt if it resizes a slice of reference types to zero length.
+// prevStmt is the statement executed immediately before assignStmt, or nil if there is none.
+func (c *checker) checkTruncation(assignStmt *ast.AssignStmt, j int, prevStmt ast.Stmt) {
+	pass := c.pass
+
+	// The LHS can be either an identifier (e.g., `x`) or a selector expression (e.g., `myObj.sliceField`).
+	var lhsExpr ast.Expr
+	var sliceName string  // The LHS as rendered for the fix, like "x" or "myObj.sliceField"
+	var reportName string // The name used in the message, if different from sliceName
+	fixable := true
+
+	// Parentheses never change what is being assigned, so strip them before classification.
+	switch lhs := ast.Unparen(assignStmt.Lhs[j]).(type) {
+	case *ast.Ident:
+		lhsExpr = lhs
+		sliceName = c.render(lhs)
+	case *ast.SelectorExpr:
+		lhsExpr = lhs
+		// If the chain is rooted at something other than an identifier (e.g., a function call returning a struct),
+		// the truncation does not write back to anything addressable, so skip it.
+		if _, ok := selectorName(lhs); !ok {
+			return
+		}
+		sliceName = c.render(lhs)
+	case *ast.StarExpr:
+		// Truncation through a pointer to a slice, e.g. `*p = (*p)[:0]`.
+		if _, ok := selectorName(lhs.X); !ok {
+			return
+		}
+		lhsExpr = lhs
+		sliceName = c.render(lhs)
+		// Through a pointer alias like `p := &state.queue`, the truncated slice is the one pointed to.
+		if target := c.resolve(lhs); target != lhs {
+			if _, ok := selectorName(target); ok {
+				reportName = c.render(target)
 			}
+		}
+	case *ast.IndexExpr:
+		// Map values are not addressable, so `m[k] = m[k][:0]` is the only way to truncate them in place.
+		if _, isMap := pass.TypesInfo.TypeOf(lhs.X).Underlying().(*types.Map); !isMap {
+			return
+		}
+		lhsExpr = lhs
+		sliceName = c.render(lhs)
+		// The map index is repeated in the replacement, so only offer a fix when the key can be evaluated repeatedly.
+		fixable = isPure(pass.TypesInfo, lhs)
+	default:
+		return // Not an identifier, selector, dereference or map index, not interested.
+	}
 
-			// Check if the high index of the slice expression is a literal "0".
-			if rhsSliceExpr.High == nil {
-				continue
+	rhsSliceExpr, ok := ast.Unparen(assignStmt.Rhs[j]).(*ast.SliceExpr)
+	if !ok {
+		return
+	}
+
+	// Ensure the right-hand side's sliced expression matches the left-hand side.
+	// This requires comparing the AST nodes themselves, not just their string names.
+	// Conversions between slice types share the backing array, so `s = Buf(s)[:0]` truncates s as well.
+	if !identicalExpr(pass.TypesInfo, lhsExpr, unconvert(pass.TypesInfo, rhsSliceExpr.X)) {
+		return
+	}
+
+	// Truncating to zero length is the special case of truncating to n where every element stays behind.
+	// Emptying from the end, `buf = buf[len(buf):]`, keeps every element behind like `buf[:0]` does.
+	// Advancing the head, `q = q[i:]`, leaves the consumed prefix behind instead of the tail.
+	// The shapes are disjoint, so a statement gets at most one of them.
+	zeroLength := c.isZeroLength(rhsSliceExpr)
+	atOffset := !zeroLength && c.isEmptyAtOffset(rhsSliceExpr, lhsExpr)
+	zeroLength = zeroLength || atOffset
+	partial := !zeroLength && c.reportPartial && c.isPartialTruncation(rhsSliceExpr, lhsExpr)
+	advance := !zeroLength && !partial && c.reportAdvance && c.isAdvance(rhsSliceExpr, lhsExpr)
+	if !zeroLength && !partial && !advance {
+		return
+	}
+
+	// A parameter is a copy of the caller's slice header; truncating it is a logic bug whatever the elements are.
+	if param := c.deadParamCopy(assignStmt, lhsExpr); param != nil {
+		c.reportParamCopy(assignStmt, j, param)
+		return
+	}
+	// The same goes for a field of a value receiver, which is a copy of the caller's struct.
+	if recv := c.deadReceiverCopy(assignStmt, lhsExpr); recv != nil {
+		c.reportReceiverCopy(assignStmt, j, recv)
+		return
+	}
+
+	// Check if the element type of the slice itself is a reference type.
+	elemType, ok := c.referenceElem(lhsExpr)
+	if !ok {
+		// Secrets in byte slices are a concern of their own, whatever the garbage collector does.
+		if c.secrets && zeroLength {
+			c.checkSecretTruncation(assignStmt, j, lhsExpr, sliceName, prevStmt)
+		}
+		return
+	}
+
+	// The value variable of a range statement is a copy of the element, so unless the truncated copy is used
+	// afterwards, the truncation does nothing to the collection.
+	if rangeStmt := c.rangeCopyOf(assignStmt, lhsExpr); rangeStmt != nil {
+		c.reportRangeCopy(assignStmt, j, rangeStmt, rhsSliceExpr)
+		return
+	}
+
+	if c.clearedEarlier(assignStmt, lhsExpr, prevStmt) || c.clearedOnAllPaths(c.listCursor, assignStmt, lhsExpr) ||
+		c.clearedLater(assignStmt, lhsExpr) || c.zeroedByIndex(assignStmt, lhsExpr) {
+		// Found a clear() call for the same slice before the truncation (or zero assignments to each of its
+		// elements), or one re-extending it right after.
+		// This is a false positive, so skip reporting for this assignment.
+		return
+	}
+	// In assume-move mode, the elements of a slice just appended elsewhere stay reachable anyway.
+	if c.assumeMove && c.movedBefore(assignStmt, lhsExpr) {
+		return
+	}
+	// A slice that never held any element has nothing to clear.
+	if c.isProvablyEmpty(lhsExpr) {
+		return
+	}
+	// Nor does a truncation whose header is replaced by a fresh allocation before anything observes it.
+	if c.reallocatedAfter(assignStmt, lhsExpr) {
+		return
+	}
+	// Nor does a truncation of a local that is never used again, such as the last statement touching it.
+	if c.isDeadLocalStore(assignStmt, lhsExpr) {
+		return
+	}
+
+	if reportName == "" {
+		reportName = sliceName
+	}
+
+	// If we reach here, it means no preceding clear() was found, so report the diagnostic.
+	// A plain assignment is reported as a whole, while each pair of a tuple assignment is reported at its own RHS.
+	startPos := assignStmt.Pos()
+	endPos := assignStmt.End()
+	if len(assignStmt.Lhs) > 1 {
+		startPos = assignStmt.Rhs[j].Pos()
+		endPos = assignStmt.Rhs[j].End()
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:      startPos,
+		End:      endPos,
+		Category: categoryTruncation,
+		Message:  "slice " + reportName + " of type " + elemType.String() + " is resized to zero length without clearing elements",
+	}
+	switch {
+	case atOffset:
+		diagnostic.Message = "slice " + reportName + " of type " + elemType.String() + " is resized to zero length at index " +
+			c.sourceOf(rhsSliceExpr.Low) + " without clearing elements; the result cannot reuse the capacity before that index either"
+		// slices.Delete would move the empty slice to the start of the backing array and give it back the full capacity,
+		// so the fix clears the elements and keeps the original reslice instead.
+		fixable = fixable && len(assignStmt.Lhs) == 1 && assignStmt == c.listStmt
+		if text, imports, ok := c.clearText(assignStmt.Pos(), lhsExpr, elemType); fixable && ok {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+				classify(c.safetyOf(assignStmt.Pos(), lhsExpr), analysis.SuggestedFix{
+					Message:   "Clear the elements before reslicing.",
+					TextEdits: append([]analysis.TextEdit{{Pos: assignStmt.Pos(), End: assignStmt.Pos(), NewText: []byte(text)}}, imports...),
+				}),
 			}
-			highLit, ok := rhsSliceExpr.High.(*ast.BasicLit)
-			if !ok || highLit.Value != "0" {
-				continue
+		}
+		fixable = false
+	case partial:
+		high := c.sourceOf(rhsSliceExpr.High)
+		diagnostic.Message = "slice " + reportName + " of type " + elemType.String() + " is resized to length " + high +
+			" without clearing elements; " + reportName + "[" + high + ":] remains reachable through the backing array"
+		// slices.Delete(x, n, len(x)) clears x[n:] and keeps the capacity, as x[:n] does. n is spelled once, as written,
+		// so it may contain calls. The tail may not exist if n exceeds len(x) (x[:n] may also extend up to cap(x)),
+		// in which case slices.Delete panics, so the fix needs verification unless n is len(x)-k for a constant k.
+		pkg, imports, hasSlices := c.slicesName(assignStmt.Pos())
+		if fixable && !rhsSliceExpr.Slice3 && hasSlices && c.deleteClears(assignStmt.Pos()) {
+			class := fixVerify
+			if x, k := lenMinus(pass.TypesInfo, rhsSliceExpr.High); x != nil && identicalExpr(pass.TypesInfo, x, lhsExpr) &&
+				k.Kind() == constant.Int && constant.Sign(k) >= 0 {
+				class = c.safetyOf(assignStmt.Pos(), lhsExpr)
 			}
-
-			// Get the type of the LHS expression (the slice itself).
-			sliceType := pass.TypesInfo.TypeOf(lhsExpr)
-			if sliceType == nil {
-				continue
+			replacement := pkg + ".Delete(" + sliceName + ", " + c.sourceOf(rhsSliceExpr.High) + ", len(" + sliceName + "))"
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+				classify(class, analysis.SuggestedFix{
+					Message: "Replace with slices.Delete to clear the elements beyond the new length.",
+					TextEdits: append([]analysis.TextEdit{
+						{
+							Pos:     assignStmt.Rhs[j].Pos(),
+							End:     assignStmt.Rhs[j].End(),
+							NewText: []byte(keepExprComments(pass, assignStmt.Rhs[j].Pos(), assignStmt.Rhs[j].End(), replacement)),
+						},
+					}, imports...),
+				}),
+			}
+		}
+		fixable = false
+	case advance:
+		low := c.sourceOf(rhsSliceExpr.Low)
+		// The message names slices.Delete as the fix does, under the name the file imports it by, if any.
+		pkg, _, hasSlices := c.slicesName(assignStmt.Pos())
+		if !hasSlices {
+			pkg = "slices"
+		}
+		deleteText := pkg + ".Delete(" + reportName + ", 0, " + low + ")"
+		diagnostic.Message = "slice " + reportName + " of type " + elemType.String() + " is advanced to index " + low +
+			" without clearing elements; the consumed elements before it remain reachable through the backing array; " +
+			"clear them before advancing, or use " + deleteText + ", which also moves the remaining elements to index 0"
+		diagnostic.SuggestedFixes = c.advanceFixes(assignStmt, j, lhsExpr, rhsSliceExpr.Low, elemType, fixable)
+		fixable = false
+	}
+	if tp, isTypeParam := elemType.(*types.TypeParam); isTypeParam {
+		diagnostic.Message += " (based on the constraint " + tp.Constraint().String() + " of type parameter " + tp.Obj().Name() + ")"
+	}
+	if c.inReuseMethod() {
+		diagnostic.Category = categoryReusePoint
+		diagnostic.Message += " (this method is a reuse point; retained elements survive until the next fill)"
+	}
+	// The clear-style alternative keeps the truncation as written and clears the elements in a statement of its own
+	// before it.
+	clearable := fixable && len(assignStmt.Lhs) == 1 && assignStmt == c.listStmt
+	pkg, imports, hasSlices := c.slicesName(assignStmt.Pos())
+	replacement := pkg + ".Delete(" + sliceName + ", 0, len(" + sliceName + "))"
+	// A local holding zero as the new length stays in the replacement, where it may be its only use.
+	high := "0"
+	zeroHigh := rhsSliceExpr.High == nil || isZeroConst(pass.TypesInfo, rhsSliceExpr.High)
+	if !zeroHigh {
+		high = c.sourceOf(rhsSliceExpr.High)
+	}
+	unstableCap := false
+	if rhsSliceExpr.Slice3 {
+		// A full slice expression controls the capacity of the result (`s[:0:cap(s)]` keeps it, `s[:0:0]` drops it),
+		// so the fix re-applies the author's capacity to the cleared slice, unless it is the capacity slices.Delete
+		// keeps anyway. slices.Delete (or clear) runs before the capacity is evaluated, so this is only equivalent
+		// when the capacity does not read the elements.
+		switch {
+		case isCapOf(pass.TypesInfo, rhsSliceExpr.Max, lhsExpr):
+			if !zeroHigh {
+				replacement += "[:" + high + "]"
+			}
+		case isStableCap(pass.TypesInfo, rhsSliceExpr.Max, lhsExpr):
+			replacement += "[:" + high + ":" + c.render(rhsSliceExpr.Max) + "]"
+		default:
+			fixable, clearable, unstableCap = false, false, true
+		}
+	} else if !zeroHigh {
+		replacement += "[:" + high + "]"
+	}
+	// Before Go 1.22, slices.Delete leaves the removed elements in place, so only the clear-style fix helps.
+	deleteClears := hasSlices && c.deleteClears(assignStmt.Pos())
+	var fixes []analysis.SuggestedFix
+	if c.fixTemplate != "" {
+		// The statement of the template takes the place of slices.Delete, whatever the Go version. It replaces
+		// the whole truncation, so tuple assignments, the capacity of full slice expressions and a local holding the
+		// new length cannot be kept.
+		if fixable && len(assignStmt.Lhs) == 1 && !rhsSliceExpr.Slice3 && zeroHigh {
+			if fix, ok := c.templateFix(assignStmt, lhsExpr); ok {
+				fixes = append(fixes, fix)
 			}
+		}
+	} else if fixable && deleteClears {
+		// Only the truncating RHS is rewritten, so the edits of sibling pairs never overlap
+		// and the other expressions of a tuple assignment are preserved byte-for-byte.
+		fixes = append(fixes, classify(c.safetyOf(assignStmt.Pos(), lhsExpr), analysis.SuggestedFix{
+			Message: "Replace with slices.Delete to clear elements before len adjustment.",
+			TextEdits: append([]analysis.TextEdit{
+				{
+					Pos:     assignStmt.Rhs[j].Pos(),
+					End:     assignStmt.Rhs[j].End(),
+					NewText: []byte(keepExprComments(pass, assignStmt.Rhs[j].Pos(), assignStmt.Rhs[j].End(), replacement)),
+				},
+			}, imports...),
+		}))
+	}
+	if text, imports, ok := c.clearText(assignStmt.Pos(), lhsExpr, elemType); clearable && ok {
+		clearFix := analysis.SuggestedFix{
+			Message:   "Clear the elements before truncating.",
+			TextEdits: append([]analysis.TextEdit{{Pos: assignStmt.Pos(), End: assignStmt.Pos(), NewText: []byte(text)}}, imports...),
+		}
+		if !c.goVersionAtLeast(assignStmt.Pos(), "go1.21") {
+			clearFix.Message = "Zero the elements in a loop before truncating."
+		}
+		clearFix = classify(c.safetyOf(assignStmt.Pos(), lhsExpr), clearFix)
+		// Drivers applying a single fix take the first one, so the preferred style goes first.
+		if c.fixStyle == fixStyleClear {
+			fixes = append([]analysis.SuggestedFix{clearFix}, fixes...)
+		} else {
+			fixes = append(fixes, clearFix)
+		}
+	}
+	if fixes != nil {
+		diagnostic.SuggestedFixes = fixes
+	} else if unstableCap {
+		diagnostic.Message += " (no fix is suggested: the capacity " + c.render(rhsSliceExpr.Max) +
+			" may read the elements, which the fix would clear before it is evaluated)"
+	} else if fixable && c.fixTemplate == "" && !c.goVersionAtLeast(assignStmt.Pos(), "go1.21") {
+		diagnostic.Message += " (no fix is suggested: Go " + strings.TrimPrefix(c.goVersion(assignStmt.Pos()), "go") +
+			" has neither clear nor slices.Delete, and the elements cannot be zeroed in a loop here)"
+	} else if fixable && c.fixTemplate == "" && !deleteClears {
+		diagnostic.Message += " (no fix is suggested: slices.Delete only clears the removed elements from Go 1.22, " +
+			"and clear needs a statement of its own here)"
+	}
+	pass.Report(diagnostic)
+	c.truncated = append(c.truncated, truncationSite{stmt: assignStmt, target: lhsExpr})
+}
 
-			slice, ok := sliceType.Underlying().(*types.Slice)
-			if !ok {
-				continue
+// clearText returns the statement clearing the elements of target, of element type elemType, to be inserted in front
+// of the statement at pos: clear(target), or before Go 1.21, which has no clear builtin, a loop assigning the zero
+// value to every element, along with the edits importing the packages the zero value refers to. It returns false if
+// the zero value or a free index variable cannot be spelled.
+func (c *checker) clearText(pos token.Pos, target ast.Expr, elemType types.Type) (string, []analysis.TextEdit, bool) {
+	name, indent := c.render(target), c.indentAt(pos)
+	if c.goVersionAtLeast(pos, "go1.21") {
+		return "clear(" + name + ")\n" + indent, nil, true
+	}
+	zero, imports, ok := c.zeroLiteral(pos, elemType)
+	if !ok {
+		return "", nil, false
+	}
+	index, ok := freeIndex(target)
+	if !ok {
+		return "", nil, false
+	}
+	elem := name
+	if _, isStar := target.(*ast.StarExpr); isStar {
+		elem = "(" + name + ")"
+	}
+	return "for " + index + " := range " + name + " {\n" + indent + "\t" + elem + "[" + index + "] = " + zero + "\n" + indent + "}\n" + indent, imports, true
+}
+
+// freeIndex returns the name of an index variable for a loop over target, or for the loop target itself, that does
+// not hide a variable target refers to, or false if there is none among i, j, k and n.
+func freeIndex(target ast.Node) (string, bool) {
+	candidates := []string{"i", "j", "k", "n"}
+	i := slices.IndexFunc(candidates, func(name string) bool { return !mentionsName(target, name) })
+	if i < 0 {
+		return "", false
+	}
+	return candidates[i], true
+}
+
+// mentionsName reports whether node contains an identifier spelled name.
+func mentionsName(node ast.Node, name string) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// inReuseMethod reports whether the statements being checked belong to a method named in the reuse-methods list.
+// Closures inside such a method count as part of it.
+func (c *checker) inReuseMethod() bool {
+	return c.funcDecl != nil && c.funcDecl.Recv != nil && slices.Contains(c.reuseMethods, c.funcDecl.Name.Name)
+}
+
+// checkAliasingDecl reports the j-th LHS/RHS pair of assignStmt if it binds a zero-length view of a different slice,
+// as in `scratch := pool.buf[:0]`. The old elements stay reachable through the source slice's backing array,
+// even though the new variable appears to start fresh. There is no mechanical fix, so none is suggested.
+func (c *checker) checkAliasingDecl(assignStmt *ast.AssignStmt, j int) {
+	pass := c.pass
+
+	lhs := ast.Unparen(assignStmt.Lhs[j])
+	if ident, ok := lhs.(*ast.Ident); ok && ident.Name == "_" {
+		return
+	}
+	rhsSliceExpr, ok := ast.Unparen(assignStmt.Rhs[j]).(*ast.SliceExpr)
+	if !ok || !c.isZeroLength(rhsSliceExpr) {
+		return
+	}
+	// Self-truncations are the main check's business.
+	if identicalExpr(pass.TypesInfo, lhs, unconvert(pass.TypesInfo, rhsSliceExpr.X)) {
+		return
+	}
+	// Only named slices have a backing array that outlives this statement.
+	srcName, ok := selectorName(rhsSliceExpr.X)
+	if !ok {
+		return
+	}
+	elemType, ok := c.referenceElem(rhsSliceExpr.X)
+	if !ok {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      assignStmt.Rhs[j].Pos(),
+		End:      assignStmt.Rhs[j].End(),
+		Category: categoryAliasing,
+		Message:  c.sourceOf(lhs) + " aliases the backing array of slice " + srcName + " of type " + elemType.String() + "; the old elements remain reachable through " + srcName,
+	})
+}
+
+// isZeroLength reports whether a slice expression selects zero elements from the start of its operand,
+// i.e. whether its high index is zero and its low index is absent or the constant zero, as in `s[:0]` or `s[0:0]`.
+// Constants are folded, so `s[:zero]`, `s[:0x0]` and `s[:int(0)]` all count, and so does a local variable
+// that provably holds zero. A variable low index is left alone even if it happens to be zero at run time.
+func (c *checker) isZeroLength(sliceExpr *ast.SliceExpr) bool {
+	if sliceExpr.Low != nil && !isZeroConst(c.pass.TypesInfo, sliceExpr.Low) {
+		return false
+	}
+	if sliceExpr.High == nil {
+		return false
+	}
+	if isZeroConst(c.pass.TypesInfo, sliceExpr.High) {
+		return true
+	}
+	ident, ok := ast.Unparen(sliceExpr.High).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := c.pass.TypesInfo.Uses[ident].(*types.Var)
+	return ok && c.zeroVars[v]
+}
+
+// isEmptyAtOffset reports whether sliceExpr is an empty reslice of target that does not start at zero:
+// target[len(target):], target[len(target):len(target)] or target[i:i] for a variable or constant i.
+func (c *checker) isEmptyAtOffset(sliceExpr *ast.SliceExpr, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	if sliceExpr.Low == nil || sliceExpr.Slice3 || isZeroConst(info, sliceExpr.Low) {
+		return false
+	}
+	if isLenOf(info, sliceExpr.Low, target) {
+		return sliceExpr.High == nil || isLenOf(info, sliceExpr.High, target)
+	}
+	// Only operands without side effects are compared, so that both bounds are known to evaluate to the same index.
+	switch low := ast.Unparen(sliceExpr.Low).(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return sliceExpr.High != nil && identicalExpr(info, low, sliceExpr.High)
+	default:
+		return false
+	}
+}
+
+// indentAt returns the whitespace preceding pos on its line, for code inserted before the statement at pos.
+func (c *checker) indentAt(pos token.Pos) string {
+	return lineIndent(c.pass, pos)
+}
+
+// lineIndent is indentAt for the files of pass.
+func lineIndent(pass *analysis.Pass, pos token.Pos) string {
+	position := pass.Fset.Position(pos)
+	content, err := pass.ReadFile(position.Filename)
+	if err != nil || position.Offset > len(content) {
+		return ""
+	}
+	start := bytes.LastIndexByte(content[:position.Offset], '\n') + 1
+	indent := content[start:position.Offset]
+	if len(bytes.TrimLeft(indent, " \t")) != 0 {
+		return ""
+	}
+	return string(indent)
+}
+
+// sourceOf returns the source text of expr as written, falling back to its canonical rendering.
+// Inserted code built from sourceOf matches the formatting of the surrounding code.
+func (c *checker) sourceOf(expr ast.Expr) string {
+	if text, ok := c.readSource(expr); ok {
+		return text
+	}
+	return types.ExprString(expr)
+}
+
+// render returns expr printed as gofmt would, for fixes and messages to spell it the same way. Unlike
+// types.ExprString, it keeps every part of the expression, such as parentheses, conversions and literals.
+func (c *checker) render(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, c.pass.Fset, expr); err != nil {
+		return types.ExprString(expr)
+	}
+	return buf.String()
+}
+
+// readSource returns the source text of node.
+func (c *checker) readSource(node ast.Node) (string, bool) {
+	start, end := c.pass.Fset.Position(node.Pos()), c.pass.Fset.Position(node.End())
+	content, err := c.pass.ReadFile(start.Filename)
+	if err != nil || start.Filename != end.Filename || end.Offset > len(content) {
+		return "", false
+	}
+	return string(content[start.Offset:end.Offset]), true
+}
+
+// isPartialTruncation reports whether sliceExpr is target[:n] (or target[0:n], target[:n:m]) with n not provably
+// zero or len(target). Zero-length truncations are reported by the main check; `target[:len(target)]` keeps every element.
+func (c *checker) isPartialTruncation(sliceExpr *ast.SliceExpr, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	if sliceExpr.Low != nil && !isZeroConst(info, sliceExpr.Low) {
+		return false
+	}
+	if sliceExpr.High == nil || c.isZeroLength(sliceExpr) {
+		return false
+	}
+	return !isLenOf(info, sliceExpr.High, target)
+}
+
+// isAdvance reports whether sliceExpr is target[i:] or target[i:len(target)] with i not the constant zero.
+func (c *checker) isAdvance(sliceExpr *ast.SliceExpr, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	if sliceExpr.Low == nil || isZeroConst(info, sliceExpr.Low) || sliceExpr.Slice3 {
+		return false
+	}
+	return sliceExpr.High == nil || isLenOf(info, sliceExpr.High, target)
+}
+
+// isLenOf reports whether expr is a call of the built-in len on target.
+func isLenOf(info *types.Info, expr, target ast.Expr) bool {
+	return isBuiltinOf(info, expr, target, "len")
+}
+
+// isCapOf reports whether expr is a call of the built-in cap on target.
+func isCapOf(info *types.Info, expr, target ast.Expr) bool {
+	return isBuiltinOf(info, expr, target, "cap")
+}
+
+// isBuiltinOf reports whether expr is a call of the built-in name on target.
+func isBuiltinOf(info *types.Info, expr, target ast.Expr, name string) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+	fn, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := info.Uses[fn].(*types.Builtin)
+	return ok && b.Name() == name && identicalExpr(info, target, call.Args[0])
+}
+
+// unconvert strips conversions between slice types from expr, such as Buf(s) or []*Block(s),
+// and returns the converted operand. Function calls are left alone.
+// The fix never needs the conversion back: it rewrites the base of the LHS, whose type is already the LHS type.
+func unconvert(info *types.Info, expr ast.Expr) ast.Expr {
+	for {
+		call, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 || call.Ellipsis.IsValid() || !info.Types[call.Fun].IsType() {
+			return expr
+		}
+		if _, isSlice := info.TypeOf(call.Args[0]).Underlying().(*types.Slice); !isSlice {
+			return expr
+		}
+		expr = call.Args[0]
+	}
+}
+
+// isStableCap reports whether the capacity expression of a full slice expression over target
+// evaluates the same before and after the elements of target are cleared.
+// This holds for literals, plain identifiers, and len or cap of target itself.
+func isStableCap(info *types.Info, max, target ast.Expr) bool {
+	switch max := ast.Unparen(max).(type) {
+	case *ast.BasicLit, *ast.Ident:
+		return true
+	case *ast.CallExpr:
+		return isLenOf(info, max, target) || isCapOf(info, max, target)
+	default:
+		return false
+	}
+}
+
+// isZeroConst reports whether expr is a constant expression whose value is zero.
+func isZeroConst(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil {
+		return false
+	}
+	value := constant.ToInt(tv.Value)
+	return value.Kind() == constant.Int && constant.Sign(value) == 0
+}
+
+// referenceElem returns the element type of the slice expr if it is or contains reference types.
+func (c *checker) referenceElem(expr ast.Expr) (types.Type, bool) {
+	sliceType := c.pass.TypesInfo.TypeOf(expr)
+	if sliceType == nil {
+		return nil, false
+	}
+	slice, ok := sliceType.Underlying().(*types.Slice)
+	if !ok {
+		return nil, false
+	}
+	elemType := slice.Elem()
+	if !isOrContainsReferenceTypes(elemType, c.generic == genericConservative) {
+		return nil, false
+	}
+	return elemType, true
+}
+
+// isClearOf reports whether stmt is a call of the built-in clear on the same slice as target,
+// spelled as target itself or as the full-length reslice target[:len(target)].
+func (c *checker) isClearOf(stmt ast.Stmt, target ast.Expr) bool {
+	pass := c.pass
+
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	callExpr, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok || len(callExpr.Args) != 1 {
+		return false
+	}
+	funIdent, ok := callExpr.Fun.(*ast.Ident)
+	if !ok || funIdent.Name != "clear" {
+		return false
+	}
+	// The `clear` built-in has a nil Object but a *types.Builtin type.
+	if builtin, isBuiltin := pass.TypesInfo.Uses[funIdent].(*types.Builtin); !isBuiltin || builtin.Name() != "clear" {
+		return false
+	}
+	// Check if the argument to clear() is the same slice expression.
+	arg := callExpr.Args[0]
+	if full := fullLengthOf(pass.TypesInfo, arg); full != nil {
+		arg = full
+	}
+	return c.sameSlice(target, arg)
+}
+
+// clearedEarlier reports whether target is cleared, in the sense of clears, before assignStmt, either by prevStmt,
+// the statement executed immediately before it, or, if assignStmt is a statement of the enclosing list, by an earlier
+// statement of the list or of the lists enclosing it, as in `clear(s); if reset { s = s[:0] }`. A clear of a plain
+// copy sharing the backing array of target, as in `tmp := s; clear(tmp)`, counts as well (see aliasClearOf).
+// The backward scan stops at the first statement that could refill target after the clear: one that assigns to
+// target or one of its elements, or passes target to a function, including append. The headers of the enclosing
+// statements, such as the condition of an if, are checked the same way. The scan does not leave loops or function
+// literals, since a clear outside of them does not run before each execution of the truncation.
+func (c *checker) clearedEarlier(assignStmt *ast.AssignStmt, target ast.Expr, prevStmt ast.Stmt) bool {
+	if prevStmt != nil && (c.clears(prevStmt, target) || c.aliasClearOf(prevStmt, target) != nil) {
+		return true
+	}
+	if assignStmt != c.listStmt {
+		return false
+	}
+	// The statements and headers passed over by the scan, which must not refill an alias cleared instead of target.
+	var passed []ast.Node
+	// scan checks the statements of a list before stmt, from the closest one.
+	scan := func(stmts []ast.Stmt, stmt ast.Node) (cleared, done bool) {
+		k := slices.IndexFunc(stmts, func(s ast.Stmt) bool { return s == stmt })
+		for k--; k >= 0; k-- {
+			if c.clears(stmts[k], target) {
+				return true, true
+			}
+			if alias := c.aliasClearOf(stmts[k], target); alias != nil {
+				if !slices.ContainsFunc(passed, func(n ast.Node) bool { return c.mayRefill(n, alias) }) {
+					return true, true
+				}
+			}
+			if c.mayRefill(stmts[k], target) {
+				return false, true
 			}
+			passed = append(passed, stmts[k])
+		}
+		return false, false
+	}
+	// enclosingList returns the statement list enclosing the one at list, and the statement of it that contains list.
+	// It checks the headers of the statements in between, and fails if one of them may refill target.
+	enclosingList := func(list inspector.Cursor) (inspector.Cursor, ast.Node, bool) {
+		for cur := list; ; cur = cur.Parent() {
+			var header []ast.Node
+			switch parent := cur.Parent().Node().(type) {
+			case *ast.BlockStmt:
+				// The body of a switch or select lists clauses, which do not run one after another.
+				switch cur.Node().(type) {
+				case *ast.CaseClause, *ast.CommClause:
+					continue
+				}
+				return cur.Parent(), cur.Node(), true
+			case *ast.CaseClause, *ast.CommClause:
+				return cur.Parent(), cur.Node(), true
+			case *ast.IfStmt:
+				header = []ast.Node{parent.Init, parent.Cond}
+			case *ast.SwitchStmt:
+				header = []ast.Node{parent.Init, parent.Tag}
+			case *ast.TypeSwitchStmt:
+				header = []ast.Node{parent.Init, parent.Assign}
+			case *ast.SelectStmt, *ast.LabeledStmt:
+			default:
+				// Loops, function literals and declarations end the scan.
+				return inspector.Cursor{}, nil, false
+			}
+			for _, part := range header {
+				if part == nil {
+					continue
+				}
+				if c.mayRefill(part, target) {
+					return inspector.Cursor{}, nil, false
+				}
+				passed = append(passed, part)
+			}
+		}
+	}
+	list, stmt := c.listCursor, ast.Node(assignStmt)
+	for {
+		if cleared, done := scan(listStmts(list.Node()), stmt); done {
+			return cleared
+		}
+		var ok bool
+		if list, stmt, ok = enclosingList(list); !ok {
+			return false
+		}
+	}
+}
 
-			elemType := slice.Elem()
+// listStmts returns the statements of the statement list n, a block or a clause.
+func listStmts(n ast.Node) []ast.Stmt {
+	switch n := n.(type) {
+	case *ast.BlockStmt:
+		return n.List
+	case *ast.CaseClause:
+		return n.Body
+	case *ast.CommClause:
+		return n.Body
+	}
+	return nil
+}
 
-			// Check if the element type is a reference type.
-			if !isOrContainsReferenceTypes(elemType) {
-				continue
+// clearedLater reports whether a later statement of the list containing assignStmt zeroes the elements the truncation
+// left behind, by re-extending target in the argument of clear:
+//
+//	s = s[:0]
+//	clear(s[:cap(s)])
+//
+// or, with n saved by `n := len(s)` before the truncation, clear(s[:n]). The forward scan stops at the first statement
+// that could observe the stale elements: one that refills target in the sense of mayRefill, which includes passing it
+// to a function, or one that may return or branch away.
+func (c *checker) clearedLater(assignStmt *ast.AssignStmt, target ast.Expr) bool {
+	if assignStmt != c.listStmt {
+		return false
+	}
+	stmts := listStmts(c.listCursor.Node())
+	k := slices.IndexFunc(stmts, func(s ast.Stmt) bool { return s == assignStmt })
+	if k < 0 {
+		return false
+	}
+	for _, stmt := range stmts[k+1:] {
+		if c.isClearOfCapacity(stmt, target) || c.isClearOfSavedLength(stmts[:k], stmt, target) {
+			return true
+		}
+		if c.mayRefill(stmt, target) || leavesList(stmt) {
+			return false
+		}
+	}
+	return false
+}
+
+// isClearOfSavedLength reports whether stmt is clear(target[:n]) where n is defined by `n := len(target)` in one of
+// the statements before, is never assigned again, and target is not refilled between the definition and the end of before.
+func (c *checker) isClearOfSavedLength(before []ast.Stmt, stmt ast.Stmt, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok || c.funcDecl == nil || c.funcDecl.Body == nil {
+		return false
+	}
+	call, ok := ast.Unparen(exprStmt.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isBuiltinCall(info, call, "clear") {
+		return false
+	}
+	reslice, ok := ast.Unparen(call.Args[0]).(*ast.SliceExpr)
+	if !ok || reslice.Slice3 || (reslice.Low != nil && !isZeroConst(info, reslice.Low)) || !c.sameSlice(target, reslice.X) {
+		return false
+	}
+	ident, ok := ast.Unparen(reslice.High).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	n, ok := info.Uses[ident].(*types.Var)
+	if !ok {
+		return false
+	}
+	for m := len(before) - 1; m >= 0; m-- {
+		if assign, ok := before[m].(*ast.AssignStmt); ok && assign.Tok == token.DEFINE && len(assign.Lhs) == 1 && len(assign.Rhs) == 1 {
+			if lhs, ok := assign.Lhs[0].(*ast.Ident); ok && info.Defs[lhs] == n {
+				return isLenOf(info, assign.Rhs[0], target) && !assignedAgain(info, c.funcDecl.Body, n)
 			}
+		}
+		if c.mayRefill(before[m], target) {
+			return false
+		}
+	}
+	return false
+}
 
-			if i > 0 { // Check if there's a previous statement
-				prevStmt := stmts[i-1]
-				if exprStmt, isExprStmt := prevStmt.(*ast.ExprStmt); isExprStmt {
-					if callExpr, isCallExpr := exprStmt.X.(*ast.CallExpr); isCallExpr {
-						if funIdent, isFunIdent := callExpr.Fun.(*ast.Ident); isFunIdent {
-							// Check if the function is the built-in `clear`
-							// The `clear` built-in has a nil Object but a *types.Builtin type.
-							if funIdent.Name == "clear" {
-								if builtin, isBuiltin := pass.TypesInfo.Uses[funIdent].(*types.Builtin); isBuiltin && builtin.Name() == "clear" {
-									if len(callExpr.Args) == 1 {
-										clearArg := callExpr.Args[0]
-										// Check if the argument to clear() is the same slice expression
-										if identicalExpr(lhsExpr, clearArg) {
-											// Found a preceding clear() call for the same slice.
-											// This is a false positive, so skip reporting for this assignment.
-											continue // Continue to the next statement in the current block
-										}
-									}
-								}
-							}
-						}
-					}
+// assignedAgain reports whether v is assigned, incremented or decremented, or has its address taken in body.
+// Its definition does not count.
+func assignedAgain(info *types.Info, body *ast.BlockStmt, v *types.Var) bool {
+	assigned := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isVar(info, lhs, v) {
+					assigned = true
 				}
 			}
+		case *ast.IncDecStmt:
+			assigned = assigned || isVar(info, n.X, v)
+		case *ast.UnaryExpr:
+			assigned = assigned || (n.Op == token.AND && isVar(info, n.X, v))
+		}
+		return !assigned
+	})
+	return assigned
+}
 
-			// If we reach here, it means no preceding clear() was found, so report the diagnostic.
-			startPos := assignStmt.Pos()
-			endPos := assignStmt.End()
-
-			replacement := sliceName + " = slices.Delete(" + sliceName + ", 0, len(" + sliceName + "))"
-
-			pass.Report(analysis.Diagnostic{
-				Pos:     startPos,
-				End:     endPos,
-				Message: "slice " + sliceName + " of type " + elemType.String() + " is resized to zero length without clearing elements",
-				SuggestedFixes: []analysis.SuggestedFix{
-					{
-						Message: "Replace with slices.Delete to clear elements before len adjustment.",
-						TextEdits: []analysis.TextEdit{
-							{
-								Pos:     startPos,
-								End:     endPos,
-								NewText: []byte(replacement),
-							},
-						},
-					},
-				},
-			})
+// leavesList reports whether stmt may return or branch, so that the statements after it may not run.
+// Function literals are not entered.
+func leavesList(stmt ast.Stmt) bool {
+	leaves := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt, *ast.BranchStmt:
+			leaves = true
 		}
+		return !leaves
 	})
+	return leaves
+}
 
-	return nil, nil
+// clears reports whether stmt clears every element of target: a clear of target or of its full capacity,
+// a range loop assigning the zero value to each element, a full-range slices.Delete assigned back to target,
+// a copy from a zero buffer, or a call of a clearing helper of the package on target.
+func (c *checker) clears(stmt ast.Stmt, target ast.Expr) bool {
+	return c.isClearOf(stmt, target) || c.isClearOfCapacity(stmt, target) || c.isZeroingLoopOf(stmt, target) ||
+		c.isFullDeleteOf(stmt, target) || c.isZeroCopyOf(stmt, target) || c.isHelperClearOf(stmt, target)
+}
+
+// mayRefill reports whether node assigns to target or one of its elements, takes its address, or passes it to a function.
+func (c *checker) mayRefill(node ast.Node, target ast.Expr) bool {
+	refills := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if index, ok := ast.Unparen(lhs).(*ast.IndexExpr); ok {
+					lhs = index.X
+				}
+				if c.sameSlice(target, lhs) {
+					refills = true
+				}
+			}
+		case *ast.CallExpr:
+			if isBuiltinCall(c.pass.TypesInfo, n, "len") || isBuiltinCall(c.pass.TypesInfo, n, "cap") {
+				break
+			}
+			for _, arg := range n.Args {
+				if c.sameSlice(target, arg) {
+					refills = true
+				}
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && c.sameSlice(target, n.X) {
+				refills = true
+			}
+		}
+		return !refills
+	})
+	return refills
 }
 
 // identicalExpr compares two ast.Expr nodes for structural equivalence.
-// It handles identifiers and selector expressions for this linter's use case.
-func identicalExpr(a, b ast.Expr) bool {
-	switch a := a.(type) {
+// It handles identifiers, selector expressions, dereferences, index expressions, literals, binary operations and calls
+// for this linter's use case.
+// Redundant parentheses on either side are ignored.
+// Identifiers are compared by the object they denote rather than by name, and field selections are compared by
+// the chain of fields they select, so a promoted field matches its explicit spelling (`s.bufs` and `s.bufferSet.bufs`).
+func identicalExpr(info *types.Info, a, b ast.Expr) bool {
+	b = ast.Unparen(b)
+	switch a := ast.Unparen(a).(type) {
 	case *ast.Ident:
 		bIdent, ok := b.(*ast.Ident)
-		return ok && a.Name == bIdent.Name
+		if !ok {
+			return false
+		}
+		if aObj, bObj := info.ObjectOf(a), info.ObjectOf(bIdent); aObj != nil || bObj != nil {
+			return aObj == bObj
+		}
+		return a.Name == bIdent.Name
 	case *ast.SelectorExpr:
 		bSel, ok := b.(*ast.SelectorExpr)
 		if !ok {
 			return false
 		}
-		return identicalExpr(a.X, bSel.X) && a.Sel.Name == bSel.Sel.Name
+		aRoot, aFields := fieldPath(info, a)
+		bRoot, bFields := fieldPath(info, bSel)
+		if aFields == nil || bFields == nil {
+			// Not field selections (e.g. qualified identifiers), so compare the selected objects directly.
+			if aObj, bObj := info.ObjectOf(a.Sel), info.ObjectOf(bSel.Sel); aObj != nil || bObj != nil {
+				return aObj == bObj && identicalExpr(info, a.X, bSel.X)
+			}
+			return identicalExpr(info, a.X, bSel.X) && a.Sel.Name == bSel.Sel.Name
+		}
+		return slices.Equal(aFields, bFields) && identicalExpr(info, aRoot, bRoot)
+	case *ast.StarExpr:
+		bStar, ok := b.(*ast.StarExpr)
+		return ok && identicalExpr(info, a.X, bStar.X)
+	case *ast.IndexExpr:
+		bIndex, ok := b.(*ast.IndexExpr)
+		if !ok {
+			return false
+		}
+		return identicalExpr(info, a.X, bIndex.X) && identicalExpr(info, a.Index, bIndex.Index)
+	case *ast.BasicLit:
+		bLit, ok := b.(*ast.BasicLit)
+		return ok && a.Kind == bLit.Kind && a.Value == bLit.Value
+	case *ast.BinaryExpr:
+		// Arithmetic on indexes, like n-1 in old[n-1].
+		bBinary, ok := b.(*ast.BinaryExpr)
+		return ok && a.Op == bBinary.Op && identicalExpr(info, a.X, bBinary.X) && identicalExpr(info, a.Y, bBinary.Y)
+	case *ast.CallExpr:
+		// Calls only appear as map keys here; they match when they are spelled identically.
+		bCall, ok := b.(*ast.CallExpr)
+		if !ok || len(a.Args) != len(bCall.Args) || a.Ellipsis.IsValid() != bCall.Ellipsis.IsValid() {
+			return false
+		}
+		for i := range a.Args {
+			if !identicalExpr(info, a.Args[i], bCall.Args[i]) {
+				return false
+			}
+		}
+		return identicalExpr(info, a.Fun, bCall.Fun)
+	default:
+		return false
+	}
+}
+
+// fieldPath flattens a chain of field selections into its root expression and the fields selected from it,
+// making the implicit selections of promoted fields explicit: both `s.bufs` and `s.bufferSet.bufs` yield
+// (s, [bufferSet, bufs]). Selections automatically dereference pointers, so an explicitly dereferenced root
+// `(*p).bufs` yields p as well. The returned fields are nil if expr is not a field selection.
+func fieldPath(info *types.Info, expr ast.Expr) (ast.Expr, []*types.Var) {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return expr, nil
+	}
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal {
+		return expr, nil
+	}
+
+	root, fields := fieldPath(info, sel.X)
+	if fields == nil {
+		if star, isStar := ast.Unparen(root).(*ast.StarExpr); isStar {
+			root = star.X
+		}
+	}
+
+	t := selection.Recv()
+	for _, index := range selection.Index() {
+		if ptr, isPtr := t.Underlying().(*types.Pointer); isPtr {
+			t = ptr.Elem()
+		}
+		st, isStruct := t.Underlying().(*types.Struct)
+		if !isStruct {
+			return expr, nil
+		}
+		field := st.Field(index)
+		fields = append(fields, field)
+		t = field.Type()
+	}
+	return root, fields
+}
+
+// selectorName renders a chain of field selectors rooted at an identifier, such as `c.state.bufs` or `(*p).tokens`.
+// It reports false for chains rooted at calls or other expressions that cannot be spelled as a plain path.
+// Redundant parentheses are dropped from the rendered name.
+func selectorName(expr ast.Expr) (string, bool) {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return expr.Name, true
+	case *ast.SelectorExpr:
+		if star, isStar := ast.Unparen(expr.X).(*ast.StarExpr); isStar {
+			// An explicit dereference keeps its required parentheses, as in `(*p).tokens`.
+			x, ok := selectorName(star.X)
+			if !ok {
+				return "", false
+			}
+			return "(*" + x + ")." + expr.Sel.Name, true
+		}
+		x, ok := selectorName(expr.X)
+		if !ok {
+			return "", false
+		}
+		return x + "." + expr.Sel.Name, true
+	default:
+		return "", false
+	}
+}
+
+// isPure reports whether expr can be repeated in a suggested fix without changing behavior: it is built from
+// identifiers, literals, constants, field selections and dereferences, indexes of arrays or slices by a constant,
+// and map indexes by a pure key. Anything containing a call, a channel receive or another operation that may have
+// side effects or depend on evaluation order is impure, and a fix would have to evaluate it once instead.
+func isPure(info *types.Info, expr ast.Expr) bool {
+	if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+		return true
+	}
+	switch expr := expr.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.ParenExpr:
+		return isPure(info, expr.X)
+	case *ast.SelectorExpr:
+		if selection, ok := info.Selections[expr]; ok && selection.Kind() != types.FieldVal {
+			return false
+		}
+		return isPure(info, expr.X)
+	case *ast.StarExpr:
+		return isPure(info, expr.X)
+	case *ast.IndexExpr:
+		if !isPure(info, expr.X) {
+			return false
+		}
+		if _, isMap := info.TypeOf(expr.X).Underlying().(*types.Map); isMap {
+			return isPure(info, expr.Index)
+		}
+		tv, ok := info.Types[expr.Index]
+		return ok && tv.Value != nil
 	default:
 		return false
 	}
@@ -203,7 +1567,9 @@ func identicalExpr(a, b ast.Expr) bool {
 
 // isOrContainsReferenceTypes checks if a given type is a reference type or a composite type that can contain references.
 // It explicitly excludes basic (primitive) types.
-func isOrContainsReferenceTypes(t types.Type) bool {
+// A type parameter is classified by the type terms of its constraint; when the constraint has none (like any),
+// assumeUnconstrained decides the result.
+func isOrContainsReferenceTypes(t types.Type, assumeUnconstrained bool) bool {
 	switch t := t.(type) {
 	case *types.Basic:
 		switch t.Kind() {
@@ -232,16 +1598,62 @@ func isOrContainsReferenceTypes(t types.Type) bool {
 		return true
 	case *types.Struct:
 		for i := 0; i < t.NumFields(); i++ {
-			if isOrContainsReferenceTypes(t.Field(i).Type()) {
+			if isOrContainsReferenceTypes(t.Field(i).Type(), assumeUnconstrained) {
 				return true
 			}
 		}
 		return false
 	case *types.Array:
-		return isOrContainsReferenceTypes(t.Elem())
+		return isOrContainsReferenceTypes(t.Elem(), assumeUnconstrained)
 	case *types.Named:
-		return isOrContainsReferenceTypes(t.Underlying())
+		// For an instance such as Batch[*Row], Underlying is the origin's underlying type with the type arguments
+		// substituted, so a field declared as T is classified by the actual type argument (*Row),
+		// including nested instances like Batch[map[string]*Row].
+		// Inside the generic code itself the fields are type parameters, classified by their constraints.
+		return isOrContainsReferenceTypes(t.Underlying(), assumeUnconstrained)
+	case *types.Alias:
+		// Aliases (including instances of generic aliases) are classified by the type they denote.
+		return isOrContainsReferenceTypes(types.Unalias(t), assumeUnconstrained)
+	case *types.TypeParam:
+		terms := constraintTerms(t.Constraint())
+		if len(terms) == 0 {
+			return assumeUnconstrained
+		}
+		for _, term := range terms {
+			if isOrContainsReferenceTypes(term, assumeUnconstrained) {
+				return true
+			}
+		}
+		return false
 	default:
 		return false
 	}
 }
+
+// constraintTerms returns the type terms of a constraint interface, including those of embedded constraints.
+// It returns nil for constraints that only have methods (or nothing at all, like any), which admit every type.
+func constraintTerms(constraint types.Type) []types.Type {
+	iface, ok := constraint.Underlying().(*types.Interface)
+	if !ok {
+		return []types.Type{constraint}
+	}
+	var terms []types.Type
+	// addTerm flattens constraint interfaces used as terms, as in `Signed | Unsigned`.
+	addTerm := func(term types.Type) {
+		if _, isIface := term.Underlying().(*types.Interface); isIface {
+			terms = append(terms, constraintTerms(term)...)
+		} else {
+			terms = append(terms, term)
+		}
+	}
+	for i := range iface.NumEmbeddeds() {
+		if union, isUnion := iface.EmbeddedType(i).(*types.Union); isUnion {
+			for j := range union.Len() {
+				addTerm(union.Term(j).Type())
+			}
+		} else {
+			addTerm(iface.EmbeddedType(i))
+		}
+	}
+	return terms
+}
@@ -1,10 +1,14 @@
 package clearslice
 
 import (
+	"fmt"
+	"path/filepath"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -12,10 +16,505 @@ func TestClearSliceAnalyzer(t *testing.T) {
 	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "a")
 }
 
+func TestNolintDirectives(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "nolint")
+	analysistest.Run(t, analysistest.TestData(), NewMapClearAnalyzer(), "nolintmap")
+}
+
+func TestIgnoreDirectives(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("require-ignore-reason", "true"))
+	require.NoError(t, a.Flags.Set("report-aliasing-decls", "true"))
+	results := analysistest.Run(t, analysistest.TestData(), a, "ignore")
+	require.Len(t, results, 1)
+	// Suppressed findings are kept for audits: five statements and the method in ignore.go,
+	// and legacy in legacy.go.
+	require.Len(t, results[0].Result.(*Result).Suppressed, 7)
+}
+
+func TestBaseline(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("baseline", filepath.Join(analysistest.TestData(), "baseline", "baseline.json")))
+	results := analysistest.Run(t, analysistest.TestData(), a, "baseline")
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Result.(*Result).Suppressed, 4)
+}
+
+// unmetWants records the errors of analysistest instead of failing the test.
+type unmetWants []string
+
+func (u *unmetWants) Errorf(format string, args ...any) {
+	*u = append(*u, fmt.Sprintf(format, args...))
+}
+
+func TestWriteBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("baseline", filepath.Join(analysistest.TestData(), "baseline", "baseline.json")))
+	require.NoError(t, a.Flags.Set("write-baseline", path))
+	results := analysistest.Run(t, analysistest.TestData(), a, "baseline")
+	require.Len(t, results, 1)
+	// The written baseline keeps the known findings along with the two new ones.
+	require.Len(t, results[0].Result.(*Result).Baseline, 6)
+
+	a = NewAnalyzer()
+	require.NoError(t, a.Flags.Set("baseline", path))
+	var unmet unmetWants
+	results = analysistest.Run(&unmet, analysistest.TestData(), a, "baseline")
+	require.Len(t, results, 1)
+	require.Empty(t, results[0].Diagnostics)
+	require.Len(t, results[0].Result.(*Result).Suppressed, 6)
+	// Only the wants of the two findings that were new are left unmet.
+	require.Len(t, unmet, 2, unmet)
+}
+
+func TestSkipGenerated(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "generated")
+
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("skip-generated", "false"))
+	analysistest.Run(t, analysistest.TestData(), a, "generatedall")
+}
+
+func TestIncludeTests(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "withtests")
+
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("include-tests", "false"))
+	analysistest.Run(t, analysistest.TestData(), a, "withtestsskipped")
+}
+
+func TestExpSlicesDelete(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "expslices")
+}
+
+func TestExcludePackages(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("exclude-packages", "excluded/legacy/...,excluded/exact"))
+	analysistest.Run(t, analysistest.TestData(), a, "excluded/...")
+}
+
+func TestTupleAssignmentFixes(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "tuple")
+}
+
+func TestZeroLowBound(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "lowbound")
+}
+
+func TestZeroVarLengths(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "zerovar")
+}
+
+func TestFullSliceExpressions(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "slice3")
+}
+
+func TestFullSliceFixCapacity(t *testing.T) {
+	// The fixes of the slice3 goldens keep the capacity the truncation gives, and with it the behavior of append.
+	s := make([]*int, 2, 4)
+	require.Equal(t, cap(s[:0:cap(s)]), cap(slices.Delete(s, 0, len(s))))
+	s = make([]*int, 2, 4)
+	require.Equal(t, cap(s[:0:0]), cap(slices.Delete(s, 0, len(s))[:0:0]))
+	s = make([]*int, 2, 4)
+	require.Equal(t, cap(s[:0:len(s)]), cap(slices.Delete(s, 0, len(s))[:0:len(s)]))
+}
+
+func TestEmptyAtOffset(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "atend")
+}
+
+func TestMapValueRoundTrip(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "mapvalue")
+}
+
+func TestConvertedBases(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "conversion")
+}
+
+func TestShiftRemove(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "shift")
+}
+
+func TestSwapRemove(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "swapremove")
+}
+
+func TestPopBack(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "popback")
+}
+
+func TestAppendSplice(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "splice")
+}
+
+func TestDiscardedDelete(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "discarded")
+}
+
+func TestClearAfterTruncation(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "ineffective")
+}
+
+func TestRangeCopies(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "rangecopy")
+}
+
+func TestParamCopies(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "paramcopy")
+}
+
+func TestReceiverCopyFix(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "receiverfix", "receiverfix/frames")
+}
+
+func TestClearingHelpers(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "helpers")
+	// Helpers of other packages are known from the facts exported for them.
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "sliceutil", "helperuse")
+}
+
+func TestPoolPut(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "pool")
+}
+
+func TestReportAliasingDecls(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-aliasing-decls", "true"))
+	analysistest.Run(t, analysistest.TestData(), a, "aliasing")
+}
+
+func TestReportPartial(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-partial", "true"))
+	analysistest.Run(t, analysistest.TestData(), a, "partial")
+}
+
+func TestReportAppendReuse(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-append-reuse", "true"))
+	results := analysistest.Run(t, analysistest.TestData(), a, "appendreuse")
+	categories := map[string]int{}
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			categories[diagnostic.Category]++
+		}
+	}
+	require.Equal(t, map[string]int{categoryAppendReuse: 3, categoryTruncation: 1}, categories)
+}
+
+func TestReportSubsliceRetention(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-subslice-retention", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "retention")
+}
+
+func TestReportAdvance(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-advance", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "advance")
+}
+
+func TestReextension(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "reextend")
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			if diagnostic.Category == categoryReextension {
+				require.Len(t, diagnostic.Related, 1)
+				require.Less(t, diagnostic.Related[0].Pos, diagnostic.Pos)
+			}
+		}
+	}
+}
+
+func TestHeapPop(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "heappop")
+}
+
+func TestDeleteBounds(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "bounds")
+}
+
+func TestReportRedundantClear(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-redundant-clear", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "redundant")
+}
+
+func TestReportRealloc(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-realloc", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "realloc")
+}
+
+func TestReportCopyTail(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-copy-tail", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "copytail")
+}
+
+func TestReportAppendAlias(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-append-alias", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "appendalias")
+}
+
+func TestReportResetMake(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-reset-make", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "resetmake")
+}
+
+func TestReportElemAddr(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-elem-addr", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "elemaddr")
+}
+
+func TestReportRingSlots(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-ring-slots", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "ring")
+}
+
+func TestAssumeMove(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("assume-move", "true"))
+	analysistest.Run(t, analysistest.TestData(), a, "move")
+}
+
+func TestSecrets(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("secrets", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "secrets")
+
+	a = NewAnalyzer()
+	require.NoError(t, a.Flags.Set("secrets", "true"))
+	require.NoError(t, a.Flags.Set("secret-names", "(?i)seed"))
+	analysistest.Run(t, analysistest.TestData(), a, "secretscustom")
+	require.Error(t, NewAnalyzer().Flags.Set("secret-names", "("))
+}
+
+func TestModernizeClear(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("modernize-clear", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "modernize")
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "modernize120"), a, "./...")
+}
+
+func TestReuseMethods(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "reuse")
+	categories := map[string]int{}
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			categories[diagnostic.Category]++
+		}
+	}
+	require.Equal(t, map[string]int{categoryReusePoint: 2, categoryTruncation: 2}, categories)
+
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("reuse-methods", "Drain, Flush"))
+	analysistest.Run(t, analysistest.TestData(), a, "reusecustom")
+}
+
+func TestGenericElements(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "generic")
+
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("generic", "strict"))
+	analysistest.Run(t, analysistest.TestData(), a, "genericstrict")
+
+	require.Error(t, NewAnalyzer().Flags.Set("generic", "lenient"))
+}
+
+func TestGenericInstances(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(), "instances")
+}
+
+func TestMapClearAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewMapClearAnalyzer(), "mapclear")
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "mapclear120"), NewMapClearAnalyzer(), "./...")
+}
+
 func TestRecommendationPremise(t *testing.T) {
 	s := []string{"foo", "bar", "baz"}
 	linted := s[:0]
 	recommended := slices.Delete(s, 0, len(s))
 	require.Equal(t, linted, recommended)
 	require.Len(t, recommended, len(linted))
+
+	// From Go 1.22, slices.Delete zeroes the elements between the new and the old length, which is what makes
+	// it a clear. The slices.Delete of Go 1.21 left them in place, so files compiled for it get clear instead.
+	require.Equal(t, []string{"", "", ""}, recommended[:len(s)])
+	for v, clears := range map[string]bool{"go1.20": false, "go1.21": false, "go1.21.5": false, "go1.22": true, "go1.23": true, "": true} {
+		require.Equal(t, clears, deleteZeroesTail(v), v)
+	}
+}
+
+func TestImportEdits(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "imports")
+}
+
+func TestShadowedImport(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "shadow")
+}
+
+func TestRenderedTargets(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "render")
+}
+
+func TestImpureTargets(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "purity")
+}
+
+func TestFixStyle(t *testing.T) {
+	firstFixes := func(results []*analysistest.Result) []string {
+		var messages []string
+		for _, result := range results {
+			for _, diagnostic := range result.Diagnostics {
+				messages = append(messages, diagnostic.SuggestedFixes[0].Message)
+			}
+		}
+		return messages
+	}
+	const deleteFix, clearFix = "[safe] Replace with slices.Delete to clear elements before len adjustment.", "[safe] Clear the elements before truncating."
+
+	results := analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "fixstyle")
+	require.Equal(t, []string{deleteFix, deleteFix, deleteFix}, firstFixes(results))
+
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("fix-style", "clear"))
+	results = analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "fixstyle")
+	require.Equal(t, []string{clearFix, clearFix, deleteFix}, firstFixes(results))
+	require.Error(t, a.Flags.Set("fix-style", "zero"))
+}
+
+func TestGo120Fixes(t *testing.T) {
+	// Go 1.20 has neither clear nor slices, so the elements are zeroed in a loop, if at all.
+	results := analysistest.RunWithSuggestedFixes(t, filepath.Join(analysistest.TestData(), "go120"), NewAnalyzer(), "./...")
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			for _, fix := range diagnostic.SuggestedFixes {
+				require.Contains(t, []string{
+					"[safe] Zero the elements in a loop before truncating.",
+					"[safe] Zero the backing array of conns in a loop before putting it into the pool.",
+				}, fix.Message)
+			}
+		}
+	}
+}
+
+func TestGo121Fixes(t *testing.T) {
+	// The slices.Delete of Go 1.21 leaves the removed elements in place, so only clear is suggested.
+	results := analysistest.RunWithSuggestedFixes(t, filepath.Join(analysistest.TestData(), "go121"), NewAnalyzer(), "./...")
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			for _, fix := range diagnostic.SuggestedFixes {
+				require.Equal(t, "[safe] Clear the elements before truncating.", fix.Message)
+			}
+		}
+	}
+}
+
+func TestExpSlicesFallback(t *testing.T) {
+	// Go 1.20 predates the slices package, but the module already requires golang.org/x/exp/slices.
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-append-alias", "true"))
+	analysistest.RunWithSuggestedFixes(t, filepath.Join(analysistest.TestData(), "go120exp"), a, "./...")
+}
+
+func TestFixComments(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "comments")
+}
+
+func TestSuggestFixesDisabled(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("suggest-fixes", "false"))
+	for _, result := range analysistest.Run(t, analysistest.TestData(), a, "fixstyle", "shift", "comments") {
+		require.NotEmpty(t, result.Diagnostics)
+		for _, diagnostic := range result.Diagnostics {
+			require.Empty(t, diagnostic.SuggestedFixes, diagnostic.Message)
+		}
+	}
+}
+
+func TestFixTemplate(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("fix-template", "{{.Pkg}}.ResetSlice(&{{.Expr}})"))
+	require.NoError(t, a.Flags.Set("fix-import", "memguard"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "fixtemplate")
+}
+
+func TestBadFixTemplate(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("fix-template", "{{.Expr}} ="))
+	for _, result := range analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "badtemplate") {
+		for _, diagnostic := range result.Diagnostics {
+			for _, fix := range diagnostic.SuggestedFixes {
+				require.Equal(t, "[safe] Clear the elements before truncating.", fix.Message)
+			}
+		}
+	}
+
+	data := fixTemplateData{Expr: "s", Len: "len(s)"}
+	for _, text := range []string{"{{.Expr", "{{.Cap}}", "clear({{.Expr}}); {{.Expr}} = {{.Expr}}[:0]", "}\nfunc f() {"} {
+		_, err := (&config{fixTemplate: text}).executeFixTemplate(data)
+		require.Error(t, err, text)
+	}
+	text, err := (&config{fixTemplate: "{{.Expr}} = {{.Expr}}[:{{.Len}}]"}).executeFixTemplate(data)
+	require.NoError(t, err)
+	require.Equal(t, "s = s[:len(s)]", text)
+}
+
+func TestImportEditsPerFile(t *testing.T) {
+	// All the fixes of a file add the import with the same edits, so that applying them together adds it once.
+	results := analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), NewAnalyzer(), "multifix")
+	var imports []analysis.TextEdit
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			for _, fix := range diagnostic.SuggestedFixes {
+				for _, edit := range fix.TextEdits {
+					if strings.Contains(string(edit.NewText), `"slices"`) {
+						imports = append(imports, edit)
+					}
+				}
+			}
+		}
+	}
+	require.Len(t, imports, 7)
+	for _, edit := range imports {
+		require.Equal(t, imports[0], edit)
+	}
+}
+
+func TestFixClasses(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("fixes", "safe"))
+	results := analysistest.Run(t, analysistest.TestData(), a, "fixclass")
+	require.Len(t, results, 1)
+	for _, diagnostic := range results[0].Diagnostics {
+		for _, fix := range diagnostic.SuggestedFixes {
+			require.True(t, strings.HasPrefix(fix.Message, "[safe] "), fix.Message)
+		}
+	}
+	// The fixes dropped by -fixes=safe are recorded with the others: two for each truncation, and the move.
+	classes := make(map[string]int)
+	for _, fix := range results[0].Result.(*Result).Fixes {
+		classes[fix.Class]++
+	}
+	require.Equal(t, map[string]int{"safe": 4, "verify": 5}, classes)
+	require.Error(t, a.Flags.Set("fixes", "none"))
+}
+
+func TestFixComment(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("fix-comment", "cleared to release references for GC (clearslice)"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "fixcomment")
+	require.Error(t, a.Flags.Set("fix-comment", "two\nlines"))
+}
+
+func TestPartialFixes(t *testing.T) {
+	a := NewAnalyzer()
+	require.NoError(t, a.Flags.Set("report-partial", "true"))
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, "partialfix")
 }
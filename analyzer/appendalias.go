@@ -0,0 +1,101 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkAppendAlias reports the j-th LHS/RHS pair of assignStmt if it builds a second slice in the backing array
+// of another one, `filtered := append(s[:0], keep...)`, while s is still read later in the function. The appends
+// overwrite the elements of s from the start, whatever they are. The in-place filter idiom, where s is not read
+// again (or is overwritten before it is), is not reported, and neither is the self-reuse form `s = append(s[:0], ...)`.
+// The fix appends into a copy, slices.Clone(s)[:0], instead.
+func (c *checker) checkAppendAlias(assignStmt *ast.AssignStmt, j int) {
+	info := c.pass.TypesInfo
+
+	lhs := ast.Unparen(assignStmt.Lhs[j])
+	if ident, ok := lhs.(*ast.Ident); ok && ident.Name == "_" {
+		return
+	}
+	call, ok := ast.Unparen(assignStmt.Rhs[j]).(*ast.CallExpr)
+	if !ok || len(call.Args) < 2 || !isBuiltinCall(info, call, "append") {
+		return
+	}
+	head, ok := ast.Unparen(call.Args[0]).(*ast.SliceExpr)
+	if !ok || !c.isZeroLength(head) {
+		return
+	}
+	source := ast.Unparen(head.X)
+	name, ok := selectorName(source)
+	if !ok || c.sameSlice(lhs, source) {
+		return
+	}
+	name = c.render(source)
+	if c.funcDecl == nil || c.funcDecl.Body == nil || !c.readLater(c.funcDecl.Body, source, assignStmt.End()) {
+		return
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:      call.Pos(),
+		End:      call.End(),
+		Category: categoryAppendAlias,
+		Message: c.render(lhs) + " is built with append(" + name + "[:0], ...), which overwrites the elements of " + name +
+			" in its backing array while " + name + " is still used later; append into slices.Clone(" + name + ")[:0] or a separate buffer",
+	}
+	if pkg, imports, ok := c.slicesName(call.Pos()); ok {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(fixVerify, analysis.SuggestedFix{
+				Message: "Append into a copy of " + name + ".",
+				TextEdits: append([]analysis.TextEdit{
+					{Pos: head.Pos(), End: head.Pos(), NewText: []byte(pkg + ".Clone(")},
+					{Pos: head.X.End(), End: head.X.End(), NewText: []byte(")")},
+				}, imports...),
+			}),
+		}
+	}
+	c.pass.Report(diagnostic)
+}
+
+// readLater reports whether target is read in body after pos, before it is next overwritten as a whole.
+// Statements are considered in source order, so a read earlier in a loop body does not count.
+func (c *checker) readLater(body *ast.BlockStmt, target ast.Expr, pos token.Pos) bool {
+	read, done := false, false
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		if read || done || n == nil {
+			return false
+		}
+		if n.End() <= pos {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Pos() < pos {
+				return true
+			}
+			overwritten := false
+			for k, lhs := range n.Lhs {
+				if c.sameSlice(target, lhs) && n.Tok == token.ASSIGN {
+					overwritten = true
+					continue
+				}
+				ast.Inspect(n.Lhs[k], visit)
+			}
+			for _, rhs := range n.Rhs {
+				ast.Inspect(rhs, visit)
+			}
+			done = overwritten && !read
+			return false
+		case *ast.Ident, *ast.SelectorExpr, *ast.StarExpr:
+			if n.Pos() > pos && c.sameSlice(target, n.(ast.Expr)) {
+				read = true
+				return false
+			}
+		}
+		return true
+	}
+	ast.Inspect(body, visit)
+	return read
+}
@@ -0,0 +1,69 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkAppendReuse reports the j-th LHS/RHS pair of assignStmt if it refills a slice of reference types in place,
+// `dst = append(dst[:0], src...)`. When src is shorter than the previous contents of dst, the elements beyond the new
+// length stay reachable. Refills from a known number of elements are not reported if no assignment in the function
+// can have made dst longer than that.
+// prevStmt is the statement executed immediately before assignStmt, or nil if there is none.
+func (c *checker) checkAppendReuse(assignStmt *ast.AssignStmt, j int, prevStmt ast.Stmt) {
+	info := c.pass.TypesInfo
+
+	target := ast.Unparen(assignStmt.Lhs[j])
+	name, ok := selectorName(target)
+	if !ok {
+		return
+	}
+	call, ok := ast.Unparen(assignStmt.Rhs[j]).(*ast.CallExpr)
+	if !ok || len(call.Args) < 2 {
+		return
+	}
+	if fn, ok := ast.Unparen(call.Fun).(*ast.Ident); !ok {
+		return
+	} else if b, ok := info.Uses[fn].(*types.Builtin); !ok || b.Name() != "append" {
+		return
+	}
+	head, ok := ast.Unparen(call.Args[0]).(*ast.SliceExpr)
+	if !ok || !identicalExpr(info, target, unconvert(info, head.X)) || !c.isZeroLength(head) {
+		return
+	}
+	// append(s[:0], s[i:]...) removes a prefix in place, which is the append-splice check's business.
+	if tail, ok := ast.Unparen(call.Args[len(call.Args)-1]).(*ast.SliceExpr); ok && call.Ellipsis.IsValid() && identicalExpr(info, target, tail.X) {
+		return
+	}
+
+	if n, ok := appendedLen(call); ok {
+		if prev, ok := c.maxConstLen(target); ok && n >= prev {
+			return
+		}
+	}
+	elemType, ok := c.referenceElem(target)
+	if !ok {
+		return
+	}
+	if prevStmt != nil && (c.isClearOf(prevStmt, target) || c.isClearOfCapacity(prevStmt, target)) {
+		return
+	}
+
+	startPos, endPos := assignStmt.Pos(), assignStmt.End()
+	if len(assignStmt.Lhs) > 1 {
+		startPos, endPos = assignStmt.Rhs[j].Pos(), assignStmt.Rhs[j].End()
+	}
+	diagnostic := analysis.Diagnostic{
+		Pos:      startPos,
+		End:      endPos,
+		Category: categoryAppendReuse,
+		Message: "slice " + name + " of type " + elemType.String() + " is refilled with append(" + name + "[:0], ...) without clearing elements; " +
+			"elements beyond the new length remain reachable if the refill is shorter, so clear(" + name + ") first or " +
+			"use slices.Grow and copy",
+	}
+	// There is no fix: clearing dst first would also clear src if it shares the backing array of dst,
+	// and which elements to clear afterwards depends on the previous length.
+	c.pass.Report(diagnostic)
+}
@@ -0,0 +1,143 @@
+package clearslice
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// BaselineEntry is the stable identity of a known finding in a -baseline file, which holds a JSON array of them.
+// Unlike a position, it survives unrelated edits that move the finding to another line.
+type BaselineEntry struct {
+	// Package is the import path of the package of the finding.
+	Package string `json:"package"`
+	// File is the base name of the file of the finding.
+	File string `json:"file"`
+	// Check is the check ID of the finding, like CS001, or its category if it has none.
+	Check string `json:"check"`
+	// Expr is the source text the finding covers, with runs of white space collapsed to one space.
+	Expr string `json:"expr"`
+	// Hash is the first 16 hexadecimal digits of the SHA-256 sum of the line of the finding, without its
+	// leading and trailing white space.
+	Hash string `json:"hash"`
+}
+
+// loadBaseline reads the -baseline file, once for all the packages analyzed, and returns its entries by package.
+func (c *config) loadBaseline() (map[string][]BaselineEntry, error) {
+	c.baselineOnce.Do(func() {
+		data, err := os.ReadFile(c.baseline)
+		if err != nil {
+			c.baselineErr = fmt.Errorf("reading baseline: %w", err)
+			return
+		}
+		var entries []BaselineEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			c.baselineErr = fmt.Errorf("parsing baseline %s: %w", c.baseline, err)
+			return
+		}
+		c.baselineEntries = make(map[string][]BaselineEntry)
+		for _, entry := range entries {
+			c.baselineEntries[entry.Package] = append(c.baselineEntries[entry.Package], entry)
+		}
+	})
+	return c.baselineEntries, c.baselineErr
+}
+
+// baselineEntryOf returns the identity of the finding d in pass, or false if its source cannot be read.
+func baselineEntryOf(pass *analysis.Pass, d analysis.Diagnostic) (BaselineEntry, bool) {
+	start, end := pass.Fset.Position(d.Pos), pass.Fset.Position(d.End)
+	if !d.End.IsValid() {
+		end = start
+	}
+	content, err := pass.ReadFile(start.Filename)
+	if err != nil || start.Offset > end.Offset || end.Offset > len(content) {
+		return BaselineEntry{}, false
+	}
+	lineStart := bytes.LastIndexByte(content[:start.Offset], '\n') + 1
+	lineEnd := len(content)
+	if i := bytes.IndexByte(content[start.Offset:], '\n'); i >= 0 {
+		lineEnd = start.Offset + i
+	}
+	sum := sha256.Sum256(bytes.TrimSpace(content[lineStart:lineEnd]))
+	check := checkIDs[d.Category]
+	if check == "" {
+		check = d.Category
+	}
+	return BaselineEntry{
+		Package: pass.Pkg.Path(),
+		File:    filepath.Base(start.Filename),
+		Check:   check,
+		Expr:    strings.Join(strings.Fields(string(content[start.Offset:end.Offset])), " "),
+		Hash:    hex.EncodeToString(sum[:8]),
+	}, true
+}
+
+// baselineMatcher returns a function reporting whether a finding of pass is one of the baseline entries, which it
+// uses up: each entry accounts for one finding, so a second copy of a known line is reported. It returns nil if no
+// baseline is configured.
+func (c *config) baselineMatcher(pass *analysis.Pass) (func(analysis.Diagnostic) bool, error) {
+	if c == nil || c.baseline == "" {
+		return nil, nil
+	}
+	entries, err := c.loadBaseline()
+	if err != nil {
+		return nil, err
+	}
+	remaining := make(map[BaselineEntry]int)
+	for _, entry := range entries[pass.Pkg.Path()] {
+		remaining[entry]++
+	}
+	return func(d analysis.Diagnostic) bool {
+		entry, ok := baselineEntryOf(pass, d)
+		if !ok || remaining[entry] == 0 {
+			return false
+		}
+		remaining[entry]--
+		return true
+	}, nil
+}
+
+// recordBaseline sets the entries of the package pkg to write to the -write-baseline file, if any, and writes it.
+// The analyzer has no hook for the end of a run, so the file is rewritten after each package with the entries of
+// all the packages analyzed so far; it is complete once the last one is done.
+func (c *config) recordBaseline(pkg string, entries []BaselineEntry) error {
+	if c.writeBaseline == "" {
+		return nil
+	}
+	c.writtenMu.Lock()
+	defer c.writtenMu.Unlock()
+	if c.written == nil {
+		c.written = make(map[string][]BaselineEntry)
+	}
+	c.written[pkg] = entries
+	all := []BaselineEntry{}
+	for _, entries := range c.written {
+		all = append(all, entries...)
+	}
+	slices.SortFunc(all, func(a, b BaselineEntry) int {
+		return cmp.Or(
+			strings.Compare(a.Package, b.Package),
+			strings.Compare(a.File, b.File),
+			strings.Compare(a.Check, b.Check),
+			strings.Compare(a.Expr, b.Expr),
+			strings.Compare(a.Hash, b.Hash),
+		)
+	})
+	data, err := json.MarshalIndent(all, "", "\t")
+	if err != nil {
+		return fmt.Errorf("encoding baseline: %w", err)
+	}
+	if err := os.WriteFile(c.writeBaseline, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing baseline: %w", err)
+	}
+	return nil
+}
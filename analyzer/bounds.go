@@ -0,0 +1,68 @@
+package clearslice
+
+import (
+	"go/ast"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkDeleteBounds reports calls of slices.Delete whose end index can exceed the length of the slice, which makes
+// the call panic: slices.Delete(s, i, cap(s)), which panics as soon as the capacity of s exceeds its length,
+// and a constant end index greater than the largest length a local s can hold. This is reported whatever the
+// element type, since the panic does not depend on it. The fix uses len(s) as the end index.
+func (c *checker) checkDeleteBounds(inspect *inspector.Inspector) {
+	info := c.pass.TypesInfo
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := cur.Node().(*ast.CallExpr)
+		if len(call.Args) != 3 {
+			continue
+		}
+		fn := packageFunc(info, call)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "slices" || fn.Name() != "Delete" {
+			continue
+		}
+		c.funcDecl = nil
+		for decl := range cur.Enclosing((*ast.FuncDecl)(nil)) {
+			c.funcDecl = decl.Node().(*ast.FuncDecl)
+			break
+		}
+
+		target, end := call.Args[0], call.Args[2]
+		name := c.sourceOf(target)
+		var message string
+		if capCall, ok := ast.Unparen(end).(*ast.CallExpr); ok && len(capCall.Args) == 1 && isBuiltinCall(info, capCall, "cap") &&
+			identicalExpr(info, target, capCall.Args[0]) {
+			message = "slices.Delete with end index cap(" + name + ") panics when the capacity of " + name + " exceeds its length; use len(" + name + ")"
+		} else if n, ok := constInt(info, end); ok {
+			maxLen, known := c.maxConstLen(target)
+			if !known || n <= maxLen {
+				continue
+			}
+			message = "slices.Delete with end index " + strconv.FormatInt(n, 10) + " panics: " + name + " holds at most " +
+				strconv.FormatInt(maxLen, 10) + " elements; use len(" + name + ")"
+		} else {
+			continue
+		}
+
+		diagnostic := analysis.Diagnostic{
+			Pos:      call.Pos(),
+			End:      call.End(),
+			Category: categoryDeleteBounds,
+			Message:  message,
+		}
+		// len(s) must evaluate to the same slice as the first argument, so only named slices are fixed.
+		if _, ok := selectorName(target); ok {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+				classify(fixVerify, analysis.SuggestedFix{
+					Message: "Use len(" + name + ") as the end index.",
+					TextEdits: []analysis.TextEdit{
+						{Pos: end.Pos(), End: end.End(), NewText: []byte(keepExprComments(c.pass, end.Pos(), end.End(), "len("+name+")"))},
+					},
+				}),
+			}
+		}
+		c.pass.Report(diagnostic)
+	}
+}
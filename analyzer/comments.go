@@ -0,0 +1,107 @@
+package clearslice
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// commentsIn returns the comments of the package that lie between pos and end, in source order.
+func commentsIn(pass *analysis.Pass, pos, end token.Pos) []*ast.Comment {
+	var comments []*ast.Comment
+	for _, file := range pass.Files {
+		if pos < file.FileStart || pos > file.FileEnd {
+			continue
+		}
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if pos <= comment.Pos() && comment.End() <= end {
+					comments = append(comments, comment)
+				}
+			}
+		}
+	}
+	return comments
+}
+
+// keepStmtComments returns text, which replaces the statements between pos and end, preceded by the comments
+// written among them, each on a line of its own at the indentation of pos. Comments before pos and on the line
+// after end are outside the edit and stay as they are.
+func keepStmtComments(pass *analysis.Pass, pos, end token.Pos, text string) string {
+	var b strings.Builder
+	for _, comment := range commentsIn(pass, pos, end) {
+		b.WriteString(comment.Text + "\n" + lineIndent(pass, pos))
+	}
+	return b.String() + text
+}
+
+// keepExprComments returns text, which replaces the expression between pos and end, followed by the comments
+// written within it. Line comments become general comments, as the rest of the line follows them.
+func keepExprComments(pass *analysis.Pass, pos, end token.Pos, text string) string {
+	for _, comment := range commentsIn(pass, pos, end) {
+		if body, ok := strings.CutPrefix(comment.Text, "//"); ok {
+			if strings.Contains(body, "*/") {
+				continue
+			}
+			text += " /*" + body + " */"
+		} else {
+			text += " " + comment.Text
+		}
+	}
+	return text
+}
+
+// fixComment is a flag.Value holding the text of the line comment fixes insert, if any. The text must fit on one line.
+type fixComment string
+
+func (t *fixComment) String() string { return string(*t) }
+
+func (t *fixComment) Set(value string) error {
+	if strings.ContainsAny(value, "\r\n") {
+		return errors.New("invalid fix comment: want a single line")
+	}
+	*t = fixComment(strings.TrimSpace(value))
+	return nil
+}
+
+// withFixComment returns fix with the line comment `// text` inserted above the line of its first edit, at the
+// indentation of the line, so that reviewers of the applied fix see why the line changed. An edit starting at the
+// beginning of the line, like an inserted clear, gets the comment in front of its own text, so that the two insertions
+// cannot be applied in the wrong order. The fix is left as it is if one of its edits spans the beginning of the line.
+func withFixComment(pass *analysis.Pass, fix analysis.SuggestedFix, text fixComment) analysis.SuggestedFix {
+	if text == "" || len(fix.TextEdits) == 0 {
+		return fix
+	}
+	tf := pass.Fset.File(fix.TextEdits[0].Pos)
+	if tf == nil {
+		return fix
+	}
+	content, err := pass.ReadFile(tf.Name())
+	offset := tf.Offset(fix.TextEdits[0].Pos)
+	if err != nil || offset > len(content) {
+		return fix
+	}
+	lineStart := bytes.LastIndexByte(content[:offset], '\n') + 1
+	indent := len(content[lineStart:]) - len(bytes.TrimLeft(content[lineStart:], " \t"))
+	start := tf.Pos(lineStart + indent)
+	comment := "// " + string(text) + "\n" + string(content[lineStart:lineStart+indent])
+
+	edits := slices.Clone(fix.TextEdits)
+	for i, edit := range edits {
+		switch {
+		case edit.Pos == start:
+			edits[i].NewText = append([]byte(comment), edit.NewText...)
+			fix.TextEdits = edits
+			return fix
+		case edit.Pos < start && start < edit.End:
+			return fix
+		}
+	}
+	fix.TextEdits = append(edits, analysis.TextEdit{Pos: start, End: start, NewText: []byte(comment)})
+	return fix
+}
@@ -0,0 +1,295 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkCopyTail recognizes a copy into a reused destination buffer at stmts[i], `n := copy(dst, src)`, where dst is
+// a slice of reference types held in a struct field or obtained from a sync.Pool. When src is shorter than dst,
+// dst[n:] keeps the old elements reachable while only the first n are treated as valid. The copy is reported unless
+// a later statement of the block shrinks, clears or otherwise writes dst; the fix clears the tail, clear(dst[n:]).
+// A copy discarding its count, `copy(dst, src)` or `_ = copy(dst, src)`, is replaced with
+// clear(dst[copy(dst, src):]) instead, when dst and src can be evaluated in either order and dst a second time.
+// The lengths involved are rarely known statically, which is why only reused buffers qualify.
+func (c *checker) checkCopyTail(stmts []ast.Stmt, i int) {
+	info := c.pass.TypesInfo
+
+	var count *ast.Ident // nil if the count is discarded
+	var copyExpr ast.Expr
+	switch stmt := stmts[i].(type) {
+	case *ast.AssignStmt:
+		if (stmt.Tok != token.DEFINE && stmt.Tok != token.ASSIGN) || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return
+		}
+		ident, ok := stmt.Lhs[0].(*ast.Ident)
+		if !ok {
+			return
+		}
+		if ident.Name != "_" {
+			count = ident
+		}
+		copyExpr = stmt.Rhs[0]
+	case *ast.ExprStmt:
+		copyExpr = stmt.X
+	default:
+		return
+	}
+	stmt := stmts[i]
+	call, ok := ast.Unparen(copyExpr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || !isBuiltinCall(info, call, "copy") {
+		return
+	}
+	dst := ast.Unparen(call.Args[0])
+	name, ok := selectorName(dst)
+	if star, isStar := dst.(*ast.StarExpr); isStar {
+		name, ok = selectorName(star.X)
+		name = "(*" + name + ")"
+	}
+	if !ok || !c.isReusedBuffer(dst) {
+		return
+	}
+	elemType, ok := c.referenceElem(dst)
+	if !ok {
+		return
+	}
+
+	// Any later write to dst, or clear or copy into it, is taken to handle the tail.
+	for _, stmt := range stmts[i+1:] {
+		handled := false
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if c.sameSlice(dst, lhs) {
+						handled = true
+					}
+				}
+			case *ast.CallExpr:
+				if (isBuiltinCall(info, n, "clear") || isBuiltinCall(info, n, "copy")) && len(n.Args) > 0 {
+					arg := ast.Unparen(n.Args[0])
+					if sliceExpr, ok := arg.(*ast.SliceExpr); ok {
+						arg = sliceExpr.X
+					}
+					handled = handled || c.sameSlice(dst, arg)
+				}
+			}
+			return !handled
+		})
+		if handled {
+			return
+		}
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:      stmt.Pos(),
+		End:      stmt.End(),
+		Category: categoryCopyTail,
+	}
+	// Clearing the tail only changes what reads beyond the copied elements see.
+	class := fixVerify
+	if !c.readsBeyond(dst, count, stmt.End()) {
+		class = c.safetyOf(stmt.Pos(), dst)
+	}
+	fixable := c.goVersionAtLeast(stmt.Pos(), "go1.21")
+
+	if count == nil {
+		tail := name + "[" + c.render(call) + ":]"
+		diagnostic.Message = "copy into reused buffer " + name + " of type " + elemType.String() + " discards the copied count and leaves " +
+			"the elements beyond it reachable when the source is shorter; clear them with clear(" + tail + ")"
+		// The copy moves into the slice expression, after another evaluation of dst. Neither may call a function
+		// that could change the other, or dst itself.
+		if fixable && isPure(info, dst) && isPureSlice(info, call.Args[1]) {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+				classify(class, analysis.SuggestedFix{
+					Message: "Clear the elements beyond the copied count.",
+					TextEdits: []analysis.TextEdit{
+						{Pos: stmt.Pos(), End: stmt.End(), NewText: []byte(keepStmtComments(c.pass, stmt.Pos(), stmt.End(), "clear("+tail+")"))},
+					},
+				}),
+			}
+		}
+		c.pass.Report(diagnostic)
+		return
+	}
+
+	tail := name + "[" + count.Name + ":]"
+	diagnostic.Message = "copy into reused buffer " + name + " of type " + elemType.String() + " leaves " + tail +
+		" reachable when the source is shorter; clear it with clear(" + tail + ") or truncate " + name + " to " + count.Name
+	if fixable {
+		// The clear goes right before the next statement, so that a trailing comment stays on the copy.
+		edit := analysis.TextEdit{Pos: stmt.End(), End: stmt.End(), NewText: []byte("\n" + c.indentAt(stmt.Pos()) + "clear(" + tail + ")")}
+		if i+1 < len(stmts) {
+			next := stmts[i+1].Pos()
+			edit = analysis.TextEdit{Pos: next, End: next, NewText: []byte("clear(" + tail + ")\n" + c.indentAt(next))}
+		}
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(class, analysis.SuggestedFix{
+				Message:   "Clear the elements beyond the copied count.",
+				TextEdits: []analysis.TextEdit{edit},
+			}),
+		}
+	}
+	c.pass.Report(diagnostic)
+}
+
+// isPureSlice reports whether expr is pure (see isPure), or a slice expression of a pure operand by pure indexes.
+func isPureSlice(info *types.Info, expr ast.Expr) bool {
+	sliceExpr, ok := ast.Unparen(expr).(*ast.SliceExpr)
+	if !ok {
+		return isPure(info, expr)
+	}
+	for _, index := range []ast.Expr{sliceExpr.Low, sliceExpr.High, sliceExpr.Max} {
+		if index != nil && !isPure(info, index) {
+			return false
+		}
+	}
+	return isPure(info, sliceExpr.X)
+}
+
+// readsBeyond reports whether the elements of dst beyond count, the copied count (nil if discarded), may be read
+// after the copy ending at end: if the function uses dst after end other than as dst[:count], as an operand of len
+// or cap, as the slice a clear or copy writes, or by assigning it. A field may be read beyond the count anywhere else
+// in the package too, and a local slice wherever its variable is passed on, as in pool.Put(bp) for dst = *bp.
+func (c *checker) readsBeyond(dst ast.Expr, count *ast.Ident, end token.Pos) bool {
+	info := c.pass.TypesInfo
+	if c.funcDecl == nil {
+		return true
+	}
+	// refers reports whether n is the innermost node naming dst: the field selection, or the variable.
+	var refers func(n ast.Node) bool
+	var nodes []ast.Node
+	scope := func(pos token.Pos) bool { return pos >= end }
+	root := dst
+	if star, ok := root.(*ast.StarExpr); ok {
+		root = ast.Unparen(star.X)
+	}
+	switch root := root.(type) {
+	case *ast.SelectorExpr:
+		field := info.Selections[root].Obj()
+		refers = func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			return ok && info.Selections[sel] != nil && info.Selections[sel].Obj() == field
+		}
+		scope = func(pos token.Pos) bool { return pos < c.funcDecl.Pos() || pos >= end }
+		for _, file := range c.pass.Files {
+			nodes = append(nodes, file)
+		}
+	case *ast.Ident:
+		v := info.Uses[root]
+		refers = func(n ast.Node) bool {
+			ident, ok := n.(*ast.Ident)
+			return ok && info.Uses[ident] == v
+		}
+		nodes = append(nodes, c.funcDecl)
+	default:
+		return true
+	}
+
+	// isDst reports whether expr is dst, or the same field of another value.
+	isDst := func(expr ast.Expr) bool {
+		expr = ast.Unparen(expr)
+		if _, isStar := dst.(*ast.StarExpr); isStar {
+			star, ok := expr.(*ast.StarExpr)
+			if !ok {
+				return false
+			}
+			expr = ast.Unparen(star.X)
+		}
+		return refers(expr)
+	}
+	// The uses that read no element beyond the count.
+	allowed := make(map[ast.Node]bool)
+	allow := func(expr ast.Expr) {
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if n != nil && refers(n) {
+				allowed[n] = true
+				return false
+			}
+			return true
+		})
+	}
+	reads := false
+	for _, node := range nodes {
+		ast.Inspect(node, func(n ast.Node) bool {
+			if reads || n == nil {
+				return false
+			}
+			switch n := n.(type) {
+			case *ast.SliceExpr:
+				if count != nil && isDst(n.X) && (n.Low == nil || isZeroConst(info, n.Low)) && n.High != nil && identicalExpr(info, n.High, count) {
+					allow(n.X)
+				}
+			case *ast.CallExpr:
+				if (isBuiltinCall(info, n, "len") || isBuiltinCall(info, n, "cap")) && len(n.Args) == 1 && isDst(n.Args[0]) {
+					allow(n.Args[0])
+				}
+				// Clearing or copying into dst, or a reslice of it, writes the elements without reading them.
+				if (isBuiltinCall(info, n, "clear") || isBuiltinCall(info, n, "copy")) && len(n.Args) > 0 {
+					arg := ast.Unparen(n.Args[0])
+					if sliceExpr, ok := arg.(*ast.SliceExpr); ok {
+						arg = ast.Unparen(sliceExpr.X)
+					}
+					if isDst(arg) {
+						allow(arg)
+					}
+				}
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if isDst(lhs) {
+						allow(lhs)
+					}
+				}
+			}
+			if refers(n) && scope(n.Pos()) && !allowed[n] {
+				reads = true
+			}
+			return !reads
+		})
+	}
+	return reads
+}
+
+// isReusedBuffer reports whether dst is a struct field, or a local variable (or the slice a local pointer points to)
+// initialized from a sync.Pool, as in `bp := pool.Get().(*[]*T)`.
+func (c *checker) isReusedBuffer(dst ast.Expr) bool {
+	info := c.pass.TypesInfo
+	if sel, ok := dst.(*ast.SelectorExpr); ok {
+		selection, ok := info.Selections[sel]
+		return ok && selection.Kind() == types.FieldVal
+	}
+	if star, ok := dst.(*ast.StarExpr); ok {
+		dst = ast.Unparen(star.X)
+	}
+	ident, ok := dst.(*ast.Ident)
+	if !ok || c.funcDecl == nil || c.funcDecl.Body == nil {
+		return false
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	if !ok {
+		return false
+	}
+	fromPool := false
+	ast.Inspect(c.funcDecl.Body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return !fromPool
+		}
+		if lhs, ok := assign.Lhs[0].(*ast.Ident); !ok || info.ObjectOf(lhs) != v {
+			return true
+		}
+		assertion, ok := ast.Unparen(assign.Rhs[0]).(*ast.TypeAssertExpr)
+		if !ok {
+			return true
+		}
+		if get, ok := ast.Unparen(assertion.X).(*ast.CallExpr); ok {
+			fn := calledFunc(info, get)
+			fromPool = fn != nil && fn.FullName() == "(*sync.Pool).Get"
+		}
+		return !fromPool
+	})
+	return fromPool
+}
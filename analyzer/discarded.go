@@ -0,0 +1,71 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkDiscardedDelete reports an expression statement calling slices.Delete or slices.DeleteFunc.
+// Both return the shortened slice and leave the length of their argument alone, so discarding the result
+// keeps the old length (with the removed elements zeroed since Go 1.22). The fix assigns the result back.
+func (c *checker) checkDiscardedDelete(stmt *ast.ExprStmt) {
+	info := c.pass.TypesInfo
+
+	call, ok := ast.Unparen(stmt.X).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return
+	}
+	fn := packageFunc(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "slices" || (fn.Name() != "Delete" && fn.Name() != "DeleteFunc") {
+		return
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:      stmt.Pos(),
+		End:      stmt.End(),
+		Category: categoryDiscardedDelete,
+		Message:  "result of slices." + fn.Name() + " is discarded; the shortened slice is lost and " + c.render(call.Args[0]) + " keeps its old length",
+	}
+	// Only a slice that can be named can be assigned back.
+	target := ast.Unparen(call.Args[0])
+	_, ok = selectorName(target)
+	if star, isStar := target.(*ast.StarExpr); isStar {
+		_, ok = selectorName(star.X)
+	}
+	if ok {
+		name := c.render(target)
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(fixVerify, analysis.SuggestedFix{
+				Message: "Assign the result of slices." + fn.Name() + " back to " + name + ".",
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     stmt.Pos(),
+						End:     stmt.Pos(),
+						NewText: []byte(name + " = "),
+					},
+				},
+			}),
+		}
+	}
+	c.pass.Report(diagnostic)
+}
+
+// packageFunc returns the function selected from another package by call, as in slices.Delete(s, i, j),
+// also when it is explicitly instantiated, as in slices.Delete[[]*T](s, i, j). It returns nil for other calls.
+func packageFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	fun := ast.Unparen(call.Fun)
+	switch instance := fun.(type) {
+	case *ast.IndexExpr:
+		fun = ast.Unparen(instance.X)
+	case *ast.IndexListExpr:
+		fun = ast.Unparen(instance.X)
+	}
+	sel, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	fn, _ := info.Uses[sel.Sel].(*types.Func)
+	return fn
+}
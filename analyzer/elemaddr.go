@@ -0,0 +1,185 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkElementAddresses reports pointers to elements of large local slices, &records[i], and subslices of length one,
+// records[i:i+1], that are stored in struct fields, struct literals, package variables or maps, or returned.
+// Either keeps the entire backing array reachable for as long as the result is. A slice is considered large if it was
+// made with a constant length or capacity of at least largeMakeLen, or grows by appending to itself inside a loop.
+// These are heuristics, so the check is opt-in. Subslices are fixed with slices.Clone; an element address has no
+// mechanical fix, since the element is best copied into a new variable.
+func (c *checker) checkElementAddresses(inspect *inspector.Inspector) {
+	info := c.pass.TypesInfo
+
+	// Local slice variables considered large, mapped to why.
+	large := make(map[*types.Var]string)
+	localVar := func(expr ast.Expr) *types.Var {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		v, ok := info.ObjectOf(ident).(*types.Var)
+		if !ok || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+			return nil
+		}
+		return v
+	}
+	record := func(cur inspector.Cursor, v *types.Var, value ast.Expr) {
+		call, ok := ast.Unparen(value).(*ast.CallExpr)
+		if v == nil || !ok || len(call.Args) == 0 {
+			return
+		}
+		switch {
+		case isBuiltinCall(info, call, "make"):
+			for _, size := range call.Args[1:] {
+				if n, ok := constInt(info, size); ok && n >= largeMakeLen {
+					large[v] = "made with a large constant size"
+				}
+			}
+		case isBuiltinCall(info, call, "append") && localVar(call.Args[0]) == v && inLoop(cur):
+			if large[v] == "" {
+				large[v] = "grown by append in a loop"
+			}
+		}
+	}
+	for cur := range inspect.Root().Preorder((*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil)) {
+		switch n := cur.Node().(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for j, lhs := range n.Lhs {
+					record(cur, localVar(lhs), n.Rhs[j])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for j, name := range n.Names {
+					record(cur, localVar(name), n.Values[j])
+				}
+			}
+		}
+	}
+	if len(large) == 0 {
+		return
+	}
+
+	// pinned returns the large slice variable whose backing array value points into.
+	pinned := func(value ast.Expr) (*types.Var, bool) {
+		switch value := ast.Unparen(value).(type) {
+		case *ast.UnaryExpr:
+			if index, ok := ast.Unparen(value.X).(*ast.IndexExpr); ok && value.Op == token.AND {
+				if v := localVar(index.X); v != nil && large[v] != "" {
+					return v, false
+				}
+			}
+		case *ast.SliceExpr:
+			if v := localVar(value.X); v != nil && large[v] != "" && isSingleElement(info, value) {
+				return v, true
+			}
+		}
+		return nil, false
+	}
+	report := func(value ast.Expr, dst string) {
+		v, subslice := pinned(value)
+		if v == nil {
+			return
+		}
+		diagnostic := analysis.Diagnostic{
+			Pos:      value.Pos(),
+			End:      value.End(),
+			Category: categoryElemAddr,
+		}
+		if subslice {
+			diagnostic.Message = "single-element subslice of " + v.Name() + " (" + large[v] + ") " + dst +
+				" keeps its entire backing array reachable; store a copy with slices.Clone"
+			if pkg, imports, ok := c.slicesName(value.Pos()); ok {
+				diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+					classify(fixVerify, analysis.SuggestedFix{
+						Message: "Store a copy made with slices.Clone.",
+						TextEdits: append([]analysis.TextEdit{
+							{Pos: value.Pos(), End: value.Pos(), NewText: []byte(pkg + ".Clone(")},
+							{Pos: value.End(), End: value.End(), NewText: []byte(")")},
+						}, imports...),
+					}),
+				}
+			}
+		} else {
+			diagnostic.Message = "pointer to an element of " + v.Name() + " (" + large[v] + ") " + dst +
+				" keeps its entire backing array reachable; copy the element into a new variable and take its address instead"
+		}
+		c.pass.Report(diagnostic)
+	}
+	for cur := range inspect.Root().Preorder((*ast.AssignStmt)(nil), (*ast.CompositeLit)(nil), (*ast.ReturnStmt)(nil)) {
+		switch n := cur.Node().(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				continue
+			}
+			for j, lhs := range n.Lhs {
+				if c.isLongLived(lhs) {
+					report(n.Rhs[j], "stored in "+c.sourceOf(lhs))
+				}
+			}
+		case *ast.CompositeLit:
+			t := info.TypeOf(n)
+			if t == nil {
+				continue
+			}
+			if _, isStruct := t.Underlying().(*types.Struct); !isStruct {
+				continue
+			}
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						report(kv.Value, "stored in field "+key.Name+" of a "+types.TypeString(t, types.RelativeTo(c.pass.Pkg))+" literal")
+					}
+				}
+			}
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				report(result, "returned")
+			}
+		}
+	}
+}
+
+// isSingleElement reports whether sliceExpr selects exactly one element, as in s[i:i+1], s[i-1:i] or s[3:4].
+func isSingleElement(info *types.Info, sliceExpr *ast.SliceExpr) bool {
+	if sliceExpr.Low == nil || sliceExpr.High == nil {
+		return false
+	}
+	if low, ok := constInt(info, sliceExpr.Low); ok {
+		high, ok := constInt(info, sliceExpr.High)
+		return ok && high == low+1
+	}
+	one := func(expr ast.Expr) bool {
+		tv, ok := info.Types[expr]
+		return ok && tv.Value != nil && constant.Compare(constant.ToInt(tv.Value), token.EQL, constant.MakeInt64(1))
+	}
+	if sum, ok := ast.Unparen(sliceExpr.High).(*ast.BinaryExpr); ok && sum.Op == token.ADD {
+		return (identicalExpr(info, sliceExpr.Low, sum.X) && one(sum.Y)) || (identicalExpr(info, sliceExpr.Low, sum.Y) && one(sum.X))
+	}
+	if diff, ok := ast.Unparen(sliceExpr.Low).(*ast.BinaryExpr); ok && diff.Op == token.SUB {
+		return identicalExpr(info, sliceExpr.High, diff.X) && one(diff.Y)
+	}
+	return false
+}
+
+// inLoop reports whether the node at cur runs inside a loop of its enclosing function.
+func inLoop(cur inspector.Cursor) bool {
+	for enclosing := range cur.Enclosing((*ast.ForStmt)(nil), (*ast.RangeStmt)(nil), (*ast.FuncLit)(nil), (*ast.FuncDecl)(nil)) {
+		switch enclosing.Node().(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			return true
+		}
+		return false
+	}
+	return false
+}
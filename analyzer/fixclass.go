@@ -0,0 +1,160 @@
+package clearslice
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fixClass tells whether applying a suggested fix keeps the observable behavior of the code. It prefixes the message
+// of every fix, as in "[safe] Clear the elements before truncating.", so that drivers and reviewers can tell the
+// classes apart from the fix alone.
+type fixClass string
+
+const (
+	// fixSafe marks fixes that only clear elements nothing else in the function can see, or that are equivalent
+	// rewrites of the code as written.
+	fixSafe fixClass = "safe"
+	// fixVerify marks fixes that may change what the code does: they change the length, capacity or backing array
+	// of a slice, reorder statements, call code of unknown behavior, or clear elements that another slice of the
+	// function shares.
+	fixVerify fixClass = "verify"
+)
+
+// classify returns fix with its message prefixed by class.
+func classify(class fixClass, fix analysis.SuggestedFix) analysis.SuggestedFix {
+	fix.Message = "[" + string(class) + "] " + fix.Message
+	return fix
+}
+
+// classOf returns the class of fix, from the prefix of its message.
+func classOf(fix analysis.SuggestedFix) fixClass {
+	if strings.HasPrefix(fix.Message, "["+string(fixSafe)+"] ") {
+		return fixSafe
+	}
+	return fixVerify
+}
+
+// fixSet is a flag.Value selecting the classes of fixes attached to diagnostics.
+type fixSet string
+
+const (
+	// fixSetAll attaches every fix.
+	fixSetAll fixSet = "all"
+	// fixSetSafe attaches only the fixes classified safe.
+	fixSetSafe fixSet = "safe"
+)
+
+func (s *fixSet) String() string { return string(*s) }
+
+func (s *fixSet) Set(value string) error {
+	switch fixSet(value) {
+	case fixSetAll, fixSetSafe:
+		*s = fixSet(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid fix set %q: want %q or %q", value, fixSetAll, fixSetSafe)
+	}
+}
+
+// ClassifiedFix is a suggested fix of a reported finding, with the position of its diagnostic and its class,
+// "safe" or "verify". Fixes dropped by -fixes=safe or -suggest-fixes=false are recorded as well.
+type ClassifiedFix struct {
+	Pos     token.Pos
+	Message string
+	Class   string
+}
+
+// withFixPolicy returns a copy of pass whose diagnostics carry only the fixes c allows, with the -fix-comment of c,
+// appending all the fixes reported to fixes.
+func withFixPolicy(pass *analysis.Pass, c *config, fixes *[]ClassifiedFix) *analysis.Pass {
+	filtered := *pass
+	filtered.Report = func(d analysis.Diagnostic) {
+		var kept []analysis.SuggestedFix
+		for _, fix := range d.SuggestedFixes {
+			class := classOf(fix)
+			*fixes = append(*fixes, ClassifiedFix{d.Pos, fix.Message, string(class)})
+			if c.suggestFixes && (c.fixes != fixSetSafe || class == fixSafe) {
+				kept = append(kept, withFixComment(pass, fix, c.fixComment))
+			}
+		}
+		d.SuggestedFixes = kept
+		pass.Report(d)
+	}
+	return &filtered
+}
+
+// safetyOf returns the class of a fix clearing elements of target, or equivalent to the code as written except for
+// the elements it clears: safe, unless the function containing pos makes another slice share the backing array of
+// target, whose reads would then see zero values where they saw the elements before.
+func (c *checker) safetyOf(pos token.Pos, target ast.Expr) fixClass {
+	return c.safetyOfReplacing(pos, token.NoPos, target)
+}
+
+// safetyOfReplacing is safetyOf for a fix replacing the statements from pos to end, whose own copies of target, like
+// the local of `v := m[k]; v = v[:0]; m[k] = v`, go away with them.
+func (c *checker) safetyOfReplacing(pos, end token.Pos, target ast.Expr) fixClass {
+	if c.hasVisibleAlias(pos, end, target) {
+		return fixVerify
+	}
+	return fixSafe
+}
+
+// hasVisibleAlias reports whether the function declaration containing pos assigns target, a reslice of it or its
+// address to another variable or field, as in `tmp := s`, `head = s[:n]` or `p := &s`, including in the function
+// literals it contains. Assignments to the blank identifier, and those between pos and end, do not count.
+func (c *checker) hasVisibleAlias(pos, end token.Pos, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	file := c.fileOf(pos)
+	if file == nil {
+		return false
+	}
+	var body *ast.BlockStmt
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil && fn.Pos() <= pos && pos < fn.End() {
+			body = fn.Body
+		}
+	}
+	if body == nil {
+		return false
+	}
+	aliases := func(rhs ast.Expr) bool {
+		switch rhs := ast.Unparen(rhs).(type) {
+		case *ast.SliceExpr:
+			return identicalExpr(info, rhs.X, target)
+		case *ast.UnaryExpr:
+			return rhs.Op == token.AND && identicalExpr(info, rhs.X, target)
+		default:
+			return identicalExpr(info, rhs, target)
+		}
+	}
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n != nil && pos <= n.Pos() && n.End() <= end {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for j, rhs := range n.Rhs {
+				if len(n.Lhs) != len(n.Rhs) || !aliases(rhs) {
+					continue
+				}
+				if ident, ok := ast.Unparen(n.Lhs[j]).(*ast.Ident); ok && ident.Name == "_" {
+					continue
+				}
+				found = found || !identicalExpr(info, n.Lhs[j], target)
+			}
+		case *ast.ValueSpec:
+			for j, value := range n.Values {
+				if len(n.Names) == len(n.Values) && aliases(value) && n.Names[j].Name != "_" {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,96 @@
+package clearslice
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// categoryFixTemplate is the category of the warning about a -fix-template that cannot be used.
+const categoryFixTemplate = "fix-template"
+
+// fixTemplateData holds the fields available to -fix-template.
+type fixTemplateData struct {
+	// Expr is the truncated slice, like p.conns.
+	Expr string
+	// Len is its length, len(Expr).
+	Len string
+	// Pkg is the name the file refers to the -fix-import package by, empty if there is none.
+	Pkg string
+}
+
+// parsedFixTemplate returns -fix-template, parsed once for all the packages analyzed.
+func (c *config) parsedFixTemplate() (*template.Template, error) {
+	c.fixTemplateOnce.Do(func() {
+		c.fixTemplateParsed, c.fixTemplateErr = template.New("fix-template").Option("missingkey=error").Parse(c.fixTemplate)
+	})
+	return c.fixTemplateParsed, c.fixTemplateErr
+}
+
+// templateFix returns the fix replacing stmt, a truncation of target, with the statement -fix-template produces for
+// it, along with the import of -fix-import. It returns false if there is no template, or if the template cannot be
+// executed or does not produce a single statement; the first such failure of the run is reported as a warning at
+// stmt, as every other truncation would fail the same way. The statement calls code of unknown behavior, so the fix
+// is classified for verification.
+func (c *checker) templateFix(stmt *ast.AssignStmt, target ast.Expr) (analysis.SuggestedFix, bool) {
+	if c.fixTemplate == "" {
+		return analysis.SuggestedFix{}, false
+	}
+	name := c.render(target)
+	data := fixTemplateData{Expr: name, Len: "len(" + name + ")"}
+	var imports []analysis.TextEdit
+	if c.fixImport != "" {
+		data.Pkg, imports = c.importName(stmt.Pos(), c.fixImport)
+	}
+	text, err := c.executeFixTemplate(data)
+	if err != nil {
+		c.fixTemplateWarning.Do(func() {
+			c.pass.Report(analysis.Diagnostic{
+				Pos:      stmt.Pos(),
+				End:      stmt.End(),
+				Category: categoryFixTemplate,
+				Message:  "-fix-template cannot be used, so no fix calls it: " + err.Error(),
+			})
+		})
+		return analysis.SuggestedFix{}, false
+	}
+	return classify(fixVerify, analysis.SuggestedFix{
+		Message: "Replace the truncation with the -fix-template statement.",
+		TextEdits: append([]analysis.TextEdit{
+			{
+				Pos:     stmt.Pos(),
+				End:     stmt.End(),
+				NewText: []byte(keepStmtComments(c.pass, stmt.Pos(), stmt.End(), text)),
+			},
+		}, imports...),
+	}), true
+}
+
+// executeFixTemplate returns the statement -fix-template produces for data, or an error if the template
+// cannot be parsed or executed, or its output is not a single statement.
+func (c *config) executeFixTemplate(data fixTemplateData) (string, error) {
+	tmpl, err := c.parsedFixTemplate()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	text := strings.TrimSpace(buf.String())
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package p; func _() {\n"+text+"\n}", 0)
+	if err != nil {
+		return "", errors.New("output " + strconv.Quote(text) + " is not a Go statement")
+	}
+	if len(file.Decls) != 1 || len(file.Decls[0].(*ast.FuncDecl).Body.List) != 1 {
+		return "", errors.New("output " + strconv.Quote(text) + " is not a single Go statement")
+	}
+	return text, nil
+}
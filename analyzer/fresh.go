@@ -0,0 +1,151 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// reallocatedAfter reports whether the truncated header is thrown away before anything can observe it, because the
+// next write to target replaces it with a fresh allocation:
+//
+//	s = s[:0]
+//	...
+//	s = make([]*T, 0, n)
+//
+// The old backing array becomes unreachable either way, so clearing it changes nothing. The forward scan follows
+// the statement list of assignStmt and, at its end, the straight-line code after the enclosing if, switch, select or
+// block, and fails at the end of a loop body or function, and at any statement that may return or branch.
+// For a local variable whose address is never taken and that no closure captures, the statements in between must
+// not mention it. Fields and package-level variables may be read elsewhere, so those statements must not mention
+// the root variable or any field on the path, and must not call any function but a built-in.
+func (c *checker) reallocatedAfter(assignStmt *ast.AssignStmt, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	if assignStmt != c.listStmt || c.funcDecl == nil || c.funcDecl.Body == nil {
+		return false
+	}
+	root, fields := fieldPath(info, target)
+	ident, ok := ast.Unparen(root).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	if !ok {
+		return false
+	}
+	local := fields == nil && v.Pkg() != nil && v.Parent() != v.Pkg().Scope() && !escapesFunc(info, c.funcDecl.Body, v)
+	observes := func(n ast.Node) bool {
+		if mentionsVar(info, n, v) {
+			return true
+		}
+		if local {
+			return false
+		}
+		found := false
+		ast.Inspect(n, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.Ident:
+				if field, ok := info.Uses[n].(*types.Var); ok && slices.Contains(fields, field) {
+					found = true
+				}
+			case *ast.CallExpr:
+				_, isBuiltin := info.Uses[funcIdent(n.Fun)].(*types.Builtin)
+				found = found || !(isBuiltin || info.Types[n.Fun].IsType())
+			}
+			return !found
+		})
+		return found
+	}
+
+	list, stmt := c.listCursor, ast.Node(assignStmt)
+	for {
+		stmts := listStmts(list.Node())
+		k := slices.IndexFunc(stmts, func(s ast.Stmt) bool { return s == stmt })
+		for _, next := range stmts[k+1:] {
+			if c.isFreshAssignOf(next, target) {
+				return true
+			}
+			if observes(next) || leavesList(next) {
+				return false
+			}
+		}
+		if list, stmt, ok = straightLineParent(list); !ok {
+			return false
+		}
+	}
+}
+
+// straightLineParent returns the statement list in which execution continues after the end of the list at list,
+// and the statement of it that contains list. It fails for the bodies of loops, function literals and declarations.
+func straightLineParent(list inspector.Cursor) (inspector.Cursor, ast.Node, bool) {
+	for cur := list; ; cur = cur.Parent() {
+		switch cur.Parent().Node().(type) {
+		case *ast.BlockStmt:
+			// The body of a switch or select lists clauses, which do not run one after another.
+			switch cur.Node().(type) {
+			case *ast.CaseClause, *ast.CommClause:
+				continue
+			}
+			return cur.Parent(), cur.Node(), true
+		case *ast.CaseClause, *ast.CommClause:
+			return cur.Parent(), cur.Node(), true
+		case *ast.IfStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.LabeledStmt:
+		default:
+			return inspector.Cursor{}, nil, false
+		}
+	}
+}
+
+// isFreshAssignOf reports whether stmt assigns target a new backing array: a make call, a composite literal, nil,
+// or slices.Clone of another slice. The arguments of make may only refer to target through len or cap.
+func (c *checker) isFreshAssignOf(stmt ast.Stmt, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || !identicalExpr(info, assign.Lhs[0], target) {
+		return false
+	}
+	switch rhs := ast.Unparen(assign.Rhs[0]).(type) {
+	case *ast.Ident:
+		_, isNil := info.Uses[rhs].(*types.Nil)
+		return isNil
+	case *ast.CompositeLit:
+		return !mentions(info, rhs, target)
+	case *ast.CallExpr:
+		if fn := packageFunc(info, rhs); fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == "slices" && fn.Name() == "Clone" {
+			return len(rhs.Args) == 1 && !mentions(info, rhs.Args[0], target)
+		}
+		if !isBuiltinCall(info, rhs, "make") {
+			return false
+		}
+		for _, arg := range rhs.Args[1:] {
+			if call, ok := ast.Unparen(arg).(*ast.CallExpr); ok && len(call.Args) == 1 &&
+				(isBuiltinCall(info, call, "len") || isBuiltinCall(info, call, "cap")) && identicalExpr(info, call.Args[0], target) {
+				continue
+			}
+			if mentions(info, arg, target) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// escapesFunc reports whether the address of v is taken in body, or a function literal in body refers to v.
+func escapesFunc(info *types.Info, body *ast.BlockStmt, v *types.Var) bool {
+	escapes := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			escapes = escapes || mentionsVar(info, n.Body, v)
+			return false
+		case *ast.UnaryExpr:
+			escapes = escapes || (n.Op == token.AND && mentionsVar(info, n.X, v))
+		}
+		return !escapes
+	})
+	return escapes
+}
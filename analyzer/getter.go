@@ -0,0 +1,91 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkGetterCopy reports stmts[i] if it assigns the slice returned by a call to a local variable that a later
+// statement of the list truncates to zero length while its new value is never used:
+//
+//	buf := obj.Buffer()
+//	buf = buf[:0]
+//
+// The truncation only changes the local slice header; the slice the callee returned is owned elsewhere and keeps
+// its length and elements, although the author most likely meant to reset it. Tracking is limited to the statement
+// list, and the variable must not be reassigned between the two statements. The truncation is reported whatever the
+// element type, without a fix, and marked handled.
+func (c *checker) checkGetterCopy(stmts []ast.Stmt, i int) {
+	info := c.pass.TypesInfo
+
+	assign, ok := stmts[i].(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || c.funcDecl == nil || c.funcDecl.Body == nil {
+		return
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return
+	}
+	v, ok := info.ObjectOf(ident).(*types.Var)
+	if !ok || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+		return
+	}
+	if _, isSlice := v.Type().Underlying().(*types.Slice); !isSlice {
+		return
+	}
+	call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok || info.Types[call.Fun].IsType() {
+		return
+	}
+	if _, isBuiltin := info.Uses[funcIdent(call.Fun)].(*types.Builtin); isBuiltin {
+		return
+	}
+
+	for _, stmt := range stmts[i+1:] {
+		truncation, ok := stmt.(*ast.AssignStmt)
+		if !ok || !slices.ContainsFunc(truncation.Lhs, func(lhs ast.Expr) bool { return isVar(info, lhs, v) }) {
+			continue
+		}
+		// Any other assignment to v ends the tracking.
+		if len(truncation.Lhs) != 1 || len(truncation.Rhs) != 1 || truncation.Tok != token.ASSIGN {
+			return
+		}
+		sliceExpr, ok := ast.Unparen(truncation.Rhs[0]).(*ast.SliceExpr)
+		if !ok || !isVar(info, sliceExpr.X, v) || !c.isZeroLength(sliceExpr) {
+			return
+		}
+		if c.handled[truncation] || !c.unusedAfter(truncation, v) {
+			return
+		}
+		c.handled[truncation] = true
+		c.pass.Report(analysis.Diagnostic{
+			Pos:      truncation.Pos(),
+			End:      truncation.End(),
+			Category: categoryGetterCopy,
+			Message: "truncation of " + v.Name() + " only changes the local slice header: " + v.Name() + " holds the result of " +
+				c.sourceOf(call) + " and its new value is never used, so the slice it returned keeps its length and elements",
+		})
+		return
+	}
+}
+
+// isVar reports whether expr is an identifier referring to v.
+func isVar(info *types.Info, expr ast.Expr, v *types.Var) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && info.Uses[ident] == v
+}
+
+// funcIdent returns the identifier naming the function called by fun, if it is a plain or qualified identifier.
+func funcIdent(fun ast.Expr) *ast.Ident {
+	switch fun := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return fun
+	case *ast.SelectorExpr:
+		return fun.Sel
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// heapInterface is the method set of container/heap.Interface, built here so that types can be checked against it
+// without the analyzed package importing container/heap.
+var heapInterface = func() *types.Interface {
+	intType, boolType := types.Typ[types.Int], types.Typ[types.Bool]
+	anyType := types.Universe.Lookup("any").Type()
+	method := func(name string, params, results []*types.Var) *types.Func {
+		return types.NewFunc(token.NoPos, nil, name, types.NewSignatureType(nil, nil, nil, types.NewTuple(params...), types.NewTuple(results...), false))
+	}
+	param := func(name string, t types.Type) *types.Var { return types.NewParam(token.NoPos, nil, name, t) }
+	iface := types.NewInterfaceType([]*types.Func{
+		method("Len", nil, []*types.Var{param("", intType)}),
+		method("Less", []*types.Var{param("i", intType), param("j", intType)}, []*types.Var{param("", boolType)}),
+		method("Swap", []*types.Var{param("i", intType), param("j", intType)}, nil),
+		method("Push", []*types.Var{param("x", anyType)}, nil),
+		method("Pop", nil, []*types.Var{param("", anyType)}),
+	}, nil)
+	iface.Complete()
+	return iface
+}()
+
+// checkHeapPops reports Pop methods of types implementing container/heap.Interface that shrink their slice of
+// reference types without zeroing the popped slot, as in the canonical
+//
+//	old := *h
+//	n := len(old)
+//	x := old[n-1]
+//	*h = old[:n-1]
+//	return x
+//
+// which the container/heap example avoids with `old[n-1] = nil` before the truncation. The fix inserts that line.
+// Pops already matched by the stack pop idiom are left to checkPopBack.
+func (c *checker) checkHeapPops() {
+	info := c.pass.TypesInfo
+	for _, file := range c.pass.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil || fn.Name.Name != "Pop" {
+				continue
+			}
+			method, ok := info.Defs[fn.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			recv := method.Signature().Recv().Type()
+			if !types.Implements(recv, heapInterface) {
+				continue
+			}
+			c.funcDecl = fn
+			c.checkHeapPop(fn.Body.List, recv)
+		}
+	}
+}
+
+// checkHeapPop checks the statements of the Pop method of the heap type recv.
+func (c *checker) checkHeapPop(stmts []ast.Stmt, recv types.Type) {
+	info := c.pass.TypesInfo
+
+	// copies maps locals like old in `old := *h` to the slice they copy.
+	copies := make(map[types.Object]ast.Expr)
+	for k, stmt := range stmts {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		if ident, ok := assign.Lhs[0].(*ast.Ident); ok && assign.Tok == token.DEFINE {
+			if _, isSlice := info.TypeOf(ident).Underlying().(*types.Slice); isSlice {
+				copies[info.Defs[ident]] = assign.Rhs[0]
+			}
+		}
+
+		// *h = old[:n-1]
+		sliceExpr, ok := ast.Unparen(assign.Rhs[0]).(*ast.SliceExpr)
+		if !ok || assign.Tok != token.ASSIGN || c.handled[assign] || sliceExpr.High == nil || sliceExpr.Slice3 {
+			continue
+		}
+		if sliceExpr.Low != nil && !isZeroConst(info, sliceExpr.Low) {
+			continue
+		}
+		backing := ast.Unparen(sliceExpr.X)
+		if !identicalExpr(info, assign.Lhs[0], backing) {
+			ident, ok := backing.(*ast.Ident)
+			if !ok || copies[info.Uses[ident]] == nil || !identicalExpr(info, assign.Lhs[0], copies[info.Uses[ident]]) {
+				continue
+			}
+		}
+		index := sliceExpr.High
+		if !isPlainIndex(info, index) {
+			continue
+		}
+
+		// x := old[n-1] before the truncation, and no old[n-1] = nil.
+		read, zeroed := false, false
+		for _, earlier := range stmts[:k] {
+			prior, ok := earlier.(*ast.AssignStmt)
+			if !ok || len(prior.Lhs) != 1 || len(prior.Rhs) != 1 {
+				continue
+			}
+			if slot, ok := ast.Unparen(prior.Rhs[0]).(*ast.IndexExpr); ok && c.isSlot(slot, backing, index) {
+				read = true
+			}
+			if slot, ok := ast.Unparen(prior.Lhs[0]).(*ast.IndexExpr); ok && c.isSlot(slot, backing, index) && isZeroValue(info, prior.Rhs[0]) {
+				zeroed = true
+			}
+		}
+		if !read || zeroed {
+			continue
+		}
+		elemType, ok := c.referenceElem(backing)
+		if !ok {
+			continue
+		}
+
+		slot := c.sourceOf(backing) + "[" + c.sourceOf(index) + "]"
+		hint := "set " + slot + " to its zero value before shrinking"
+		if zero, _, ok := c.zeroLiteral(assign.Pos(), elemType); ok {
+			hint = "set " + slot + " = " + zero + " before shrinking"
+		}
+		c.pass.Report(analysis.Diagnostic{
+			Pos:      assign.Pos(),
+			End:      assign.End(),
+			Category: categoryHeapPop,
+			Message: "Pop method of heap type " + types.TypeString(recv, types.RelativeTo(c.pass.Pkg)) + " leaves the popped element of type " +
+				elemType.String() + " reachable through " + slot + "; " + hint + ", as the container/heap example does",
+			SuggestedFixes: c.zeroSlotFix(assign, backing, index, elemType),
+		})
+	}
+}
+
+// isSlot reports whether slot is target[index], comparing the indices by the objects they refer to.
+func (c *checker) isSlot(slot *ast.IndexExpr, target, index ast.Expr) bool {
+	info := c.pass.TypesInfo
+	return identicalExpr(info, slot.X, target) && identicalExpr(info, slot.Index, index)
+}
+
+// isPlainIndex reports whether index is built from identifiers, constants, len calls and arithmetic only,
+// so that two spellings of it evaluate to the same value between the read and the truncation.
+func isPlainIndex(info *types.Info, index ast.Expr) bool {
+	switch index := ast.Unparen(index).(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.BinaryExpr:
+		return isPlainIndex(info, index.X) && isPlainIndex(info, index.Y)
+	case *ast.CallExpr:
+		return len(index.Args) == 1 && isBuiltinCall(info, index, "len") && isPlainIndex(info, index.Args[0])
+	case *ast.StarExpr:
+		return isPlainIndex(info, index.X)
+	case *ast.SelectorExpr:
+		return isPlainIndex(info, index.X)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,296 @@
+package clearslice
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ClearsArgs is the fact exported for functions that clear slices reachable from their arguments on every call.
+// Params holds the indices of the slice parameters whose backing arrays are cleared, and Fields the slice fields
+// of the receiver that are, as dot-separated paths of field names like "state.bufs" for clear(c.state.bufs).
+// Dependent packages treat calls of such functions as clears, like calls of the clearing helpers of their own.
+type ClearsArgs struct {
+	Params []int
+	Fields []string
+}
+
+func (*ClearsArgs) AFact() {}
+
+func (f *ClearsArgs) String() string {
+	return fmt.Sprintf("ClearsArgs(params=%v, fields=%v)", f.Params, f.Fields)
+}
+
+// fieldNames returns the dot-separated names of fields, as in ClearsArgs.Fields.
+func fieldNames(fields []*types.Var) string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name()
+	}
+	return strings.Join(names, ".")
+}
+
+// findClearingHelpers records in c.helpers the functions and methods declared in the package that unconditionally
+// clear a slice parameter or a slice field of their receiver, like
+//
+//	func wipe(s []*Conn) { clear(s) }
+//	func (c *conn) clearBufs() { clear(c.read); clear(c.write) }
+//
+// The clear (or loop zeroing every element, full-range slices.Delete, or call of another clearing helper) must be
+// a top-level statement of the body, not preceded by a return, and the parameter or receiver must not be reassigned
+// before it. Clearing a copy of the slice header clears the caller's backing array as well, so value receivers count
+// too. Helpers calling helpers are resolved by iterating to a fixed point. Exported helpers are also exported as
+// ClearsArgs facts for the packages importing this one.
+func (c *checker) findClearingHelpers() {
+	info := c.pass.TypesInfo
+
+	c.helpers = make(map[*types.Func]*ClearsArgs)
+	var funcs []*ast.FuncDecl
+	for _, file := range c.pass.Files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+				funcs = append(funcs, fn)
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range funcs {
+			obj, ok := info.Defs[fn.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			summary := c.summarizeClears(fn, obj)
+			if prev := c.helpers[obj]; summary != nil && (prev == nil || !slices.Equal(prev.Params, summary.Params) || !slices.Equal(prev.Fields, summary.Fields)) {
+				c.helpers[obj] = summary
+				changed = true
+			}
+		}
+	}
+	for obj, summary := range c.helpers {
+		if obj.Exported() {
+			c.pass.ExportObjectFact(obj, summary)
+		}
+	}
+}
+
+// summarizeClears returns what the function fn, declaring obj, clears on every call, or nil if it clears nothing.
+func (c *checker) summarizeClears(fn *ast.FuncDecl, obj *types.Func) *ClearsArgs {
+	info := c.pass.TypesInfo
+	params := obj.Signature().Params()
+	var recv *types.Var
+	if fn.Recv != nil {
+		recv = obj.Signature().Recv()
+	}
+
+	summary := new(ClearsArgs)
+	reassigned := make(map[*types.Var]bool)
+	for _, stmt := range fn.Body.List {
+		for _, cleared := range c.clearedBy(stmt) {
+			v, ok := info.Uses[cleared.root].(*types.Var)
+			if !ok || reassigned[v] {
+				continue
+			}
+			switch {
+			case cleared.fields == "":
+				for i := range params.Len() {
+					if params.At(i) == v && !slices.Contains(summary.Params, i) {
+						summary.Params = append(summary.Params, i)
+					}
+				}
+			case v == recv && !slices.Contains(summary.Fields, cleared.fields):
+				summary.Fields = append(summary.Fields, cleared.fields)
+			}
+		}
+		if returnsOrAssigns(info, stmt, reassigned) {
+			break
+		}
+	}
+	if summary.Params == nil && summary.Fields == nil {
+		return nil
+	}
+	slices.Sort(summary.Params)
+	slices.Sort(summary.Fields)
+	return summary
+}
+
+// clearedRef is a slice cleared by a statement: the variable root, or its field selected by the path fields,
+// in the form of ClearsArgs.Fields.
+type clearedRef struct {
+	root   *ast.Ident
+	fields string
+}
+
+// clearedBy returns the slices stmt clears that are variables or fields of variables: the one clearedSlice returns,
+// or those a call of a clearing helper clears.
+func (c *checker) clearedBy(stmt ast.Stmt) []clearedRef {
+	info := c.pass.TypesInfo
+	ref := func(expr ast.Expr, extra string) (clearedRef, bool) {
+		root, fields := fieldPath(info, expr)
+		ident, ok := ast.Unparen(root).(*ast.Ident)
+		path := fieldNames(fields)
+		if path != "" && extra != "" {
+			path += "."
+		}
+		return clearedRef{ident, path + extra}, ok
+	}
+
+	if cleared := c.clearedSlice(stmt); cleared != nil {
+		if r, ok := ref(cleared, ""); ok {
+			return []clearedRef{r}
+		}
+		return nil
+	}
+	call, summary := c.helperCall(stmt)
+	if summary == nil {
+		return nil
+	}
+	var refs []clearedRef
+	for _, i := range summary.Params {
+		if i < len(call.Args) {
+			if r, ok := ref(call.Args[i], ""); ok {
+				refs = append(refs, r)
+			}
+		}
+	}
+	if sel, ok := c.directMethodCall(call); ok {
+		for _, field := range summary.Fields {
+			if r, ok := ref(sel.X, field); ok {
+				refs = append(refs, r)
+			}
+		}
+	}
+	return refs
+}
+
+// returnsOrAssigns records the variables assigned by stmt in assigned, and reports whether stmt may return.
+// Function literals are not entered.
+func returnsOrAssigns(info *types.Info, stmt ast.Stmt, assigned map[*types.Var]bool) bool {
+	returns := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			returns = true
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if ident, ok := ast.Unparen(lhs).(*ast.Ident); ok {
+					if v, ok := info.ObjectOf(ident).(*types.Var); ok {
+						assigned[v] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+	return returns
+}
+
+// clearedSlice returns the slice whose elements stmt clears: s for clear(s), clear(s[:len(s)]), clear(s[:cap(s)]),
+// a loop zeroing every element of s, or s = slices.Delete(s, 0, len(s)). It returns nil for any other statement.
+func (c *checker) clearedSlice(stmt ast.Stmt) ast.Expr {
+	info := c.pass.TypesInfo
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		call, ok := ast.Unparen(stmt.X).(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 || !isBuiltinCall(info, call, "clear") {
+			return nil
+		}
+		if _, isSlice := info.TypeOf(call.Args[0]).Underlying().(*types.Slice); !isSlice {
+			return nil
+		}
+		if full := fullLengthOf(info, call.Args[0]); full != nil {
+			return full
+		}
+		if full := fullCapacityOf(info, call.Args[0]); full != nil {
+			return full
+		}
+		return ast.Unparen(call.Args[0])
+	case *ast.RangeStmt:
+		if c.isZeroingLoop(stmt) {
+			if full := fullCapacityOf(info, stmt.X); full != nil {
+				return full
+			}
+			return ast.Unparen(stmt.X)
+		}
+	case *ast.AssignStmt:
+		if stmt.Tok == token.ASSIGN && len(stmt.Lhs) == 1 && len(stmt.Rhs) == 1 && isFullDelete(info, stmt.Rhs[0], stmt.Lhs[0]) && c.deleteClears(stmt.Pos()) {
+			return ast.Unparen(stmt.Lhs[0])
+		}
+	}
+	return nil
+}
+
+// isHelperClearOf reports whether stmt calls a clearing helper (see findClearingHelpers) on target: wipe(s) for a
+// helper clearing its parameter, or c.clearBufs() for a method clearing the field of c that target selects.
+// Helpers of other packages are known from their ClearsArgs facts. Receivers are compared by the objects they
+// denote, and each field separately: a method clearing only c.read does not clear c.write.
+func (c *checker) isHelperClearOf(stmt ast.Stmt, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	call, summary := c.helperCall(stmt)
+	if summary == nil {
+		return false
+	}
+	for _, i := range summary.Params {
+		if i < len(call.Args) && c.sameSlice(call.Args[i], target) {
+			return true
+		}
+	}
+	sel, ok := c.directMethodCall(call)
+	if !ok || len(summary.Fields) == 0 {
+		return false
+	}
+	recvRoot, recvFields := fieldPath(info, sel.X)
+	targetRoot, targetFields := fieldPath(info, target)
+	if !identicalExpr(info, recvRoot, targetRoot) {
+		return false
+	}
+	if len(targetFields) <= len(recvFields) || !slices.Equal(targetFields[:len(recvFields)], recvFields) {
+		return false
+	}
+	return slices.Contains(summary.Fields, fieldNames(targetFields[len(recvFields):]))
+}
+
+// helperCall returns the call of stmt and what it clears, if stmt is a call statement of a clearing helper
+// of this package or of one with a ClearsArgs fact.
+func (c *checker) helperCall(stmt ast.Stmt) (*ast.CallExpr, *ClearsArgs) {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil, nil
+	}
+	call, ok := ast.Unparen(exprStmt.X).(*ast.CallExpr)
+	if !ok {
+		return nil, nil
+	}
+	fn := typeutil.StaticCallee(c.pass.TypesInfo, call)
+	if fn == nil {
+		return nil, nil
+	}
+	if summary, ok := c.helpers[fn.Origin()]; ok {
+		return call, summary
+	}
+	if fn.Pkg() != c.pass.Pkg {
+		summary := new(ClearsArgs)
+		if c.pass.ImportObjectFact(fn.Origin(), summary) {
+			return call, summary
+		}
+	}
+	return nil, nil
+}
+
+// directMethodCall returns the selector of call if it calls a method declared for the type of its receiver expression.
+// Promoted methods select their receiver through embedded fields, which the paths of ClearsArgs.Fields do not include.
+func (c *checker) directMethodCall(call *ast.CallExpr) (*ast.SelectorExpr, bool) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+	selection, ok := c.pass.TypesInfo.Selections[sel]
+	return sel, ok && selection.Kind() == types.MethodVal && len(selection.Index()) == 1
+}
@@ -0,0 +1,250 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// categoryIgnoreDirective is the category of malformed //clearslice:ignore directives.
+const categoryIgnoreDirective = "ignore-directive"
+
+// checkIDs maps the categories of the findings to the stable check IDs that //clearslice:ignore directives name.
+var checkIDs = map[string]string{
+	categoryTruncation:        "CS001",
+	categoryReusePoint:        "CS002",
+	categoryAppendReuse:       "CS003",
+	categoryDiscardedDelete:   "CS004",
+	categoryIneffectiveClear:  "CS005",
+	categoryRangeCopy:         "CS006",
+	categoryParamCopy:         "CS007",
+	categoryReceiverCopy:      "CS008",
+	categoryGetterCopy:        "CS009",
+	categoryPoolPut:           "CS010",
+	categorySubsliceRetention: "CS011",
+	categoryRedundantClear:    "CS012",
+	categoryDeleteBounds:      "CS013",
+	categoryRealloc:           "CS014",
+	categoryCopyTail:          "CS015",
+	categoryReextension:       "CS016",
+	categoryAppendAlias:       "CS017",
+	categoryModernizeClear:    "CS018",
+	categoryResetMake:         "CS019",
+	categoryElemAddr:          "CS020",
+	categorySecret:            "CS021",
+	categoryHeapPop:           "CS022",
+	categoryRingSlot:          "CS023",
+	categoryMapClear:          "CS024",
+	categoryAliasing:          "CS025",
+}
+
+// Result is the result of the clearslice and mapclear analyzers, for use by audits: the findings that were not
+// reported because a //nolint or //clearslice:ignore directive suppressed them, and, for clearslice, the suggested
+// fixes of the reported findings with their class. With -write-baseline, Baseline holds the identities of the reported
+// findings and of those known from the -baseline file.
+type Result struct {
+	Suppressed []SuppressedFinding
+	Fixes      []ClassifiedFix
+	Baseline   []BaselineEntry
+}
+
+// SuppressedFinding is a finding suppressed by a directive, and the text of the directive comment.
+type SuppressedFinding struct {
+	Diagnostic analysis.Diagnostic
+	Directive  string
+}
+
+// ignoreDirective is a //clearslice:ignore directive, or a //clearslice:ignore-file one if file is set.
+// An empty list of checks applies to all of them.
+type ignoreDirective struct {
+	lineRange
+	file   bool
+	checks []string
+	reason string
+	text   string
+}
+
+// matches reports whether the directive applies to the finding d, by its check ID or its category.
+func (d *ignoreDirective) matches(diagnostic analysis.Diagnostic) bool {
+	if len(d.checks) == 0 {
+		return true
+	}
+	id := checkIDs[diagnostic.Category]
+	for _, check := range d.checks {
+		if (id != "" && strings.EqualFold(check, id)) || check == diagnostic.Category {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIgnore parses a directive of the form
+//
+//	//clearslice:ignore CS001,CS007 -- reused buffer cleared by caller
+//
+// with ok set if text is one at all. The check IDs (or category names) are optional and may be separated by
+// commas or spaces; the reason follows "--", up to a further "//" if any. problem describes what is wrong with a malformed directive.
+func parseIgnore(text string) (d ignoreDirective, ok bool, problem string) {
+	rest, found := strings.CutPrefix(text, "//clearslice:")
+	if !found {
+		return d, false, ""
+	}
+	verb, args, _ := strings.Cut(rest, " ")
+	switch verb {
+	case "ignore":
+	case "ignore-file":
+		d.file = true
+	default:
+		return d, true, "unknown directive //clearslice:" + verb + "; use //clearslice:ignore or //clearslice:ignore-file"
+	}
+	// As in other directives, a further // starts an ordinary comment.
+	args, _, _ = strings.Cut(args, "//")
+	checks, reason, _ := strings.Cut(args, "--")
+	d.reason = strings.TrimSpace(reason)
+	for _, check := range strings.FieldsFunc(checks, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		if !isCheck(check) {
+			return d, true, "unknown check " + check + " in //clearslice:" + verb + " directive"
+		}
+		d.checks = append(d.checks, check)
+	}
+	d.text = text
+	return d, true, ""
+}
+
+// isCheck reports whether name is a check ID like CS001 or the category of a check.
+func isCheck(name string) bool {
+	for category, id := range checkIDs {
+		if strings.EqualFold(name, id) || name == category {
+			return true
+		}
+	}
+	return false
+}
+
+// withSuppressions returns a copy of pass whose Report drops the findings suppressed by a //nolint directive naming
+// the analyzer (see nolintRanges) or a //clearslice:ignore directive, and records them in the returned result.
+// Being applied inside the analyzer, the directives work the same under every driver. A //clearslice:ignore
+// directive applies like //nolint, to its line and the node following it; //clearslice:ignore-file must come
+// before the first declaration and applies to the whole file.
+//
+// If c is not nil, malformed directives are reported, and so are directives without a reason if it requires one.
+// Only the clearslice analyzer passes its config, so the mapclear analyzer does not report them a second time.
+// The findings known from the -baseline file of c, if any, are suppressed and recorded as well (see BaselineEntry).
+// With -write-baseline, the identities of the findings that are not suppressed by a directive are recorded too.
+// Findings in generated files, other than those at reuse points (see config.reuseMethods), are dropped without a record
+// unless c disables -skip-generated, and so are those in _test.go files, of the package itself or of its external test
+// package, if c disables -include-tests.
+func withSuppressions(pass *analysis.Pass, c *config) (*analysis.Pass, *Result, error) {
+	result := new(Result)
+	inBaseline, err := c.baselineMatcher(pass)
+	if err != nil {
+		return nil, nil, err
+	}
+	nolints := make(map[*token.File][]*nolintRange)
+	ignores := make(map[*token.File][]*ignoreDirective)
+	generated := make(map[*token.File]bool)
+	for _, file := range pass.Files {
+		tf := pass.Fset.File(file.Pos())
+		if c.skipsGenerated() && ast.IsGenerated(file) {
+			generated[tf] = true
+		}
+		if r := nolintRanges(pass.Fset, file); r != nil {
+			nolints[tf] = r
+		}
+		if d := c.ignoreDirectives(pass, file); d != nil {
+			ignores[tf] = d
+		}
+	}
+	skipTests := c != nil && !c.includeTests
+	writeBaseline := c != nil && c.writeBaseline != ""
+	if len(nolints) == 0 && len(ignores) == 0 && len(generated) == 0 && inBaseline == nil && !skipTests && !writeBaseline {
+		return pass, result, nil
+	}
+
+	filtered := *pass
+	filtered.Report = func(d analysis.Diagnostic) {
+		if skipTests && strings.HasSuffix(pass.Fset.Position(d.Pos).Filename, "_test.go") {
+			return
+		}
+		if tf := pass.Fset.File(d.Pos); tf != nil {
+			// Reuse points are analyzed whatever the file, as they are where retained elements matter most.
+			if generated[tf] && d.Category != categoryReusePoint {
+				return
+			}
+			line := tf.Line(d.Pos)
+			for _, r := range nolints[tf] {
+				if r.contains(line) && r.matches(pass.Analyzer.Name) {
+					result.Suppressed = append(result.Suppressed, SuppressedFinding{d, r.text})
+					return
+				}
+			}
+			for _, r := range ignores[tf] {
+				if (r.file || r.contains(line)) && r.matches(d) {
+					result.Suppressed = append(result.Suppressed, SuppressedFinding{d, r.text})
+					return
+				}
+			}
+		}
+		if writeBaseline {
+			if entry, ok := baselineEntryOf(pass, d); ok {
+				result.Baseline = append(result.Baseline, entry)
+			}
+		}
+		if inBaseline != nil && inBaseline(d) {
+			result.Suppressed = append(result.Suppressed, SuppressedFinding{d, "baseline " + c.baseline})
+			return
+		}
+		pass.Report(d)
+	}
+	return &filtered, result, nil
+}
+
+// ignoreDirectives returns the well-formed //clearslice:ignore directives of file, reporting the others if c is not nil.
+func (c *config) ignoreDirectives(pass *analysis.Pass, file *ast.File) []*ignoreDirective {
+	problem := func(comment *ast.Comment, message string) {
+		if c != nil {
+			pass.Report(analysis.Diagnostic{
+				Pos:      comment.Slash,
+				End:      comment.End(),
+				Category: categoryIgnoreDirective,
+				Message:  message,
+			})
+		}
+	}
+
+	var directives []*ignoreDirective
+	var lines []*lineRange
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			d, ok, message := parseIgnore(comment.Text)
+			if !ok {
+				continue
+			}
+			if message != "" {
+				problem(comment, message)
+				continue
+			}
+			if d.file && len(file.Decls) > 0 && comment.Pos() > file.Decls[0].Pos() {
+				problem(comment, "//clearslice:ignore-file must come before the first declaration of the file")
+				continue
+			}
+			if d.reason == "" && c != nil && c.requireIgnoreReason {
+				problem(comment, "//clearslice:ignore directive has no reason; add one after \"--\"")
+			}
+			d.lineRange = directiveRange(pass.Fset, comment)
+			directives = append(directives, &d)
+			lines = append(lines, &d.lineRange)
+		}
+	}
+	expandRanges(pass.Fset, file, lines)
+	return directives
+}
+
+// skipsGenerated reports whether findings in generated files, marked by a "Code generated ... DO NOT EDIT." comment
+// before the package clause (see ast.IsGenerated), are dropped. The mapclear analyzer, which passes no config,
+// always drops them, as the default of -skip-generated does.
+func (c *config) skipsGenerated() bool {
+	return c == nil || c.skipGenerated
+}
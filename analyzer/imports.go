@@ -0,0 +1,223 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fileOf returns the file of the package containing pos, or nil if there is none.
+func (c *checker) fileOf(pos token.Pos) *ast.File {
+	for _, file := range c.pass.Files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
+
+// expSlicesPath is the import path of the slices package predating the standard library one.
+const expSlicesPath = "golang.org/x/exp/slices"
+
+// slicesName returns the name a fix at pos refers to the slices package by, along with the edits importing it.
+// Files compiled for a Go version predating the standard library package (Go 1.21) get golang.org/x/exp/slices
+// instead if a file of the package already imports it, so that its module requires it and the import resolves. A
+// dependency pulling it in indirectly is not enough, as the module may not require it. Otherwise slicesName returns
+// false. Its Delete never clears the removed elements before Go 1.22 (see deleteClears).
+func (c *checker) slicesName(pos token.Pos) (string, []analysis.TextEdit, bool) {
+	path := "slices"
+	if !c.goVersionAtLeast(pos, "go1.21") {
+		if !c.expSlices {
+			return "", nil, false
+		}
+		path = expSlicesPath
+	}
+	name, edits := c.importName(pos, path)
+	return name, edits, true
+}
+
+// importsPath reports whether a file of pass imports the package path.
+func importsPath(pass *analysis.Pass, path string) bool {
+	for _, file := range pass.Files {
+		for _, spec := range file.Imports {
+			if p, err := strconv.Unquote(spec.Path.Value); err == nil && p == path {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// importName returns the name the code at pos refers to the package path by, along with the edits adding an import
+// if the file has none usable there. An existing import is used under its own name, as with import sl "slices",
+// unless a local declaration shadows it at pos. A missing import is added once per file: when the package name
+// is declared anywhere in the file, by a variable named slices or another package imported under that name, or at
+// package level, it is added under a unique name instead, prefixed with the parent element of the path or std for
+// the standard library, like stdslices or expslices. All the fixes of a file thus carry the same import edits,
+// which drivers applying several of them merge into one.
+func (c *checker) importName(pos token.Pos, path string) (string, []analysis.TextEdit) {
+	base := path[strings.LastIndex(path, "/")+1:]
+	file := c.fileOf(pos)
+	if file == nil {
+		return base, nil
+	}
+	if name, ok := c.importedName(pos, path); ok {
+		return name, nil
+	}
+	prefix := "std"
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		prefix = path[strings.LastIndex(path[:i], "/")+1 : i]
+	}
+	key := fileImport{file, path}
+	if added, ok := c.addedImports[key]; ok {
+		return added.name, added.edits
+	}
+	declared := c.namesDeclaredIn(file)
+	name := base
+	for i := 1; declared[name] || c.pass.Pkg.Scope().Lookup(name) != nil; i++ {
+		name = prefix + base
+		if i > 1 {
+			name += strconv.Itoa(i)
+		}
+	}
+	spec := strconv.Quote(path)
+	if name != base {
+		spec = name + " " + spec
+	}
+	if c.addedImports == nil {
+		c.addedImports = make(map[fileImport]addedImport)
+	}
+	c.addedImports[key] = addedImport{name, addImport(file, spec)}
+	return name, c.addedImports[key].edits
+}
+
+// importedName returns the name the code at pos refers to the package path by, if the file imports it under a name
+// that no local declaration shadows at pos.
+func (c *checker) importedName(pos token.Pos, path string) (string, bool) {
+	file := c.fileOf(pos)
+	if file == nil {
+		return "", false
+	}
+	scope := c.pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		scope = c.pass.Pkg.Scope()
+	}
+	for _, spec := range file.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err != nil || p != path {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if _, obj := scope.LookupParent(name, pos); obj != nil {
+			if pkgName, ok := obj.(*types.PkgName); ok && pkgName.Imported().Path() == path {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// fileImport identifies an import path added to a file by fixes.
+type fileImport struct {
+	file *ast.File
+	path string
+}
+
+// addedImport is the name an import is added under, and the edits adding it.
+type addedImport struct {
+	name  string
+	edits []analysis.TextEdit
+}
+
+// namesDeclaredIn returns the names declared in file, including those of its imports.
+func (c *checker) namesDeclaredIn(file *ast.File) map[string]bool {
+	info := c.pass.TypesInfo
+	names := make(map[string]bool)
+	if scope := info.Scopes[file]; scope != nil {
+		for _, name := range scope.Names() {
+			names[name] = true
+		}
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && info.Defs[ident] != nil {
+			names[ident.Name] = true
+		}
+		return true
+	})
+	return names
+}
+
+// addImport returns the edits adding the import spec to file. The import goes into the first grouped import
+// declaration, in order among the imports of the same kind, standard library or not (or as a group of its own,
+// before the others for the standard library and after them otherwise, if there are none), turns a single import
+// into a group, goes into a declaration of its own after several single-line imports, or after the package clause.
+func addImport(file *ast.File, spec string) []analysis.TextEdit {
+	path := spec[strings.Index(spec, `"`):]
+	insert := func(at token.Pos, text string) []analysis.TextEdit {
+		return []analysis.TextEdit{{Pos: at, End: at, NewText: []byte(text)}}
+	}
+	isStd := func(quoted string) bool {
+		p, err := strconv.Unquote(quoted)
+		return err == nil && !strings.Contains(strings.SplitN(p, "/", 2)[0], ".")
+	}
+	std := isStd(path)
+	specEnd := func(s *ast.ImportSpec) token.Pos {
+		if s.Comment != nil {
+			return s.Comment.End()
+		}
+		return s.End()
+	}
+
+	var last *ast.GenDecl
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			break
+		}
+		last = gen
+		if !gen.Lparen.IsValid() || len(gen.Specs) == 0 {
+			continue
+		}
+		var lastSame *ast.ImportSpec
+		for _, s := range gen.Specs {
+			other := s.(*ast.ImportSpec)
+			if isStd(other.Path.Value) != std {
+				continue
+			}
+			if other.Path.Value > path {
+				return insert(other.Pos(), spec+"\n\t")
+			}
+			lastSame = other
+		}
+		switch {
+		case lastSame != nil:
+			return insert(specEnd(lastSame), "\n\t"+spec)
+		case std:
+			return insert(gen.Specs[0].Pos(), spec+"\n\n\t")
+		default:
+			return insert(specEnd(gen.Specs[len(gen.Specs)-1].(*ast.ImportSpec)), "\n\n\t"+spec)
+		}
+	}
+	if last == file.Decls[0] && len(last.Specs) == 1 && !last.Lparen.IsValid() {
+		// A single import becomes a group of two, as gofmt would not merge two declarations.
+		other := last.Specs[0].(*ast.ImportSpec)
+		sep := "\n\t"
+		if isStd(other.Path.Value) != std {
+			sep = "\n\n\t"
+		}
+		if (isStd(other.Path.Value) == std && other.Path.Value > path) || (std && !isStd(other.Path.Value)) {
+			return append(insert(other.Pos(), "(\n\t"+spec+sep), insert(specEnd(other), "\n)")...)
+		}
+		return append(insert(other.Pos(), "(\n\t"), insert(specEnd(other), sep+spec+"\n)")...)
+	}
+	if last != nil {
+		return insert(last.End(), "\nimport "+spec)
+	}
+	return insert(file.Name.End(), "\n\nimport "+spec)
+}
@@ -0,0 +1,205 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkClearAfterTruncation reports stmts[i] if it is a clear of a slice truncated to zero length by stmts[i-1],
+// as in `s = s[:0]; clear(s)`. clear only zeroes the elements up to the length of its argument, which is zero by then,
+// so the call does nothing. This is reported whatever the element type, instead of the truncation,
+// and the fix swaps the two statements. Clearing the full capacity instead, `clear(s[:cap(s)])`, makes the truncation safe.
+func (c *checker) checkClearAfterTruncation(stmts []ast.Stmt, i int) {
+	if i == 0 {
+		return
+	}
+	clearStmt, ok := stmts[i].(*ast.ExprStmt)
+	if !ok {
+		return
+	}
+	truncation, ok := stmts[i-1].(*ast.AssignStmt)
+	if !ok || truncation.Tok != token.ASSIGN || len(truncation.Lhs) != 1 || len(truncation.Rhs) != 1 {
+		return
+	}
+	sliceExpr, ok := ast.Unparen(truncation.Rhs[0]).(*ast.SliceExpr)
+	if !ok || !c.isZeroLength(sliceExpr) || !identicalExpr(c.pass.TypesInfo, truncation.Lhs[0], unconvert(c.pass.TypesInfo, sliceExpr.X)) {
+		return
+	}
+	target := truncation.Lhs[0]
+	if c.isClearOfCapacity(clearStmt, target) {
+		c.handled[truncation] = true
+		return
+	}
+	if !c.isClearOf(clearStmt, target) {
+		return
+	}
+	c.handled[truncation] = true
+
+	name := c.sourceOf(target)
+	diagnostic := analysis.Diagnostic{
+		Pos:      clearStmt.Pos(),
+		End:      clearStmt.End(),
+		Category: categoryIneffectiveClear,
+		Message: "clear(" + name + ") after truncating " + name + " to zero length has no effect; clear before the truncation, " +
+			"or clear the full capacity with clear(" + name + "[:cap(" + name + ")])",
+	}
+	clearText, ok1 := c.readSource(clearStmt)
+	truncationText, ok2 := c.readSource(truncation)
+	if ok1 && ok2 {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(fixVerify, analysis.SuggestedFix{
+				Message: "Move the clear before the truncation.",
+				TextEdits: []analysis.TextEdit{
+					{Pos: truncation.Pos(), End: truncation.End(), NewText: []byte(clearText)},
+					{Pos: clearStmt.Pos(), End: clearStmt.End(), NewText: []byte(truncationText)},
+				},
+			}),
+		}
+	}
+	c.pass.Report(diagnostic)
+}
+
+// isClearOfCapacity reports whether stmt is clear(target[:cap(target)]), which clears the elements beyond the length too.
+func (c *checker) isClearOfCapacity(stmt ast.Stmt, target ast.Expr) bool {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := ast.Unparen(exprStmt.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isBuiltinCall(c.pass.TypesInfo, call, "clear") {
+		return false
+	}
+	full := fullCapacityOf(c.pass.TypesInfo, call.Args[0])
+	return full != nil && c.sameSlice(target, full)
+}
+
+// fullCapacityOf returns s if expr is s[:cap(s)], or nil otherwise.
+func fullCapacityOf(info *types.Info, expr ast.Expr) ast.Expr {
+	return fullRangeOf(info, expr, "cap")
+}
+
+// fullLengthOf returns s if expr is s[:len(s)], or nil otherwise.
+func fullLengthOf(info *types.Info, expr ast.Expr) ast.Expr {
+	return fullRangeOf(info, expr, "len")
+}
+
+// fullRangeOf returns s if expr is s[:bound(s)] (or s[0:bound(s)]) for the built-in bound, len or cap, or nil otherwise.
+func fullRangeOf(info *types.Info, expr ast.Expr, bound string) ast.Expr {
+	full, ok := ast.Unparen(expr).(*ast.SliceExpr)
+	if !ok || full.Slice3 || (full.Low != nil && !isZeroConst(info, full.Low)) || full.High == nil {
+		return nil
+	}
+	boundCall, ok := ast.Unparen(full.High).(*ast.CallExpr)
+	if !ok || len(boundCall.Args) != 1 || !isBuiltinCall(info, boundCall, bound) || !identicalExpr(info, full.X, boundCall.Args[0]) {
+		return nil
+	}
+	return ast.Unparen(full.X)
+}
+
+// checkClearOfEmpty reports stmts[i] if it is a clear of a slice that is empty by construction: a zero-length
+// reslice such as clear(s[:0]), or a slice truncated to zero length by an earlier statement of the same block
+// that is not mentioned again in between. The fix clears the full capacity, clear(s[:cap(s)]), instead.
+// A clear right after the truncation is reported by checkClearAfterTruncation, and maps are never reported.
+func (c *checker) checkClearOfEmpty(stmts []ast.Stmt, i int) {
+	info := c.pass.TypesInfo
+
+	clearStmt, ok := stmts[i].(*ast.ExprStmt)
+	if !ok {
+		return
+	}
+	call, ok := ast.Unparen(clearStmt.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return
+	}
+	if fn, ok := ast.Unparen(call.Fun).(*ast.Ident); !ok {
+		return
+	} else if b, ok := info.Uses[fn].(*types.Builtin); !ok || b.Name() != "clear" {
+		return
+	}
+	arg := ast.Unparen(call.Args[0])
+	if _, isSlice := info.TypeOf(arg).Underlying().(*types.Slice); !isSlice {
+		return
+	}
+
+	var target ast.Expr
+	var message string
+	if sliceExpr, ok := arg.(*ast.SliceExpr); ok {
+		// clear(s[:0])
+		if !c.isZeroLength(sliceExpr) {
+			return
+		}
+		target = ast.Unparen(sliceExpr.X)
+		message = "clear of the zero-length slice " + c.sourceOf(arg) + " has no effect"
+	} else {
+		// s = s[:0]; ...; clear(s)
+		k := c.lastTruncation(stmts, i, arg)
+		if k < 0 || k == i-1 {
+			return
+		}
+		target = arg
+		line := c.pass.Fset.Position(stmts[k].Pos()).Line
+		message = "clear(" + c.sourceOf(arg) + ") has no effect: " + c.sourceOf(arg) +
+			" was truncated to zero length at line " + strconv.Itoa(line)
+	}
+	name, nameable := selectorName(target)
+	if !nameable {
+		name = c.sourceOf(target)
+	}
+	full := name + "[:cap(" + name + ")]"
+
+	diagnostic := analysis.Diagnostic{
+		Pos:      clearStmt.Pos(),
+		End:      clearStmt.End(),
+		Category: categoryIneffectiveClear,
+		Message:  message + "; clear before the truncation, or clear the full capacity with clear(" + full + ")",
+	}
+	if nameable {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(c.safetyOf(clearStmt.Pos(), target), analysis.SuggestedFix{
+				Message: "Clear the full capacity of " + name + ".",
+				TextEdits: []analysis.TextEdit{
+					{Pos: call.Args[0].Pos(), End: call.Args[0].End(), NewText: []byte(full)},
+				},
+			}),
+		}
+	}
+	c.pass.Report(diagnostic)
+}
+
+// lastTruncation returns the index of the last statement before stmts[i] that truncates target to zero length,
+// provided no statement in between mentions the variable target is rooted at, or -1 if there is none.
+func (c *checker) lastTruncation(stmts []ast.Stmt, i int, target ast.Expr) int {
+	info := c.pass.TypesInfo
+	root, _ := fieldPath(info, target)
+	rootIdent, ok := ast.Unparen(root).(*ast.Ident)
+	if !ok {
+		return -1
+	}
+	rootObj := info.ObjectOf(rootIdent)
+	if rootObj == nil {
+		return -1
+	}
+	for k := i - 1; k >= 0; k-- {
+		if truncation, ok := stmts[k].(*ast.AssignStmt); ok && truncation.Tok == token.ASSIGN && len(truncation.Lhs) == 1 && len(truncation.Rhs) == 1 {
+			sliceExpr, ok := ast.Unparen(truncation.Rhs[0]).(*ast.SliceExpr)
+			if ok && c.isZeroLength(sliceExpr) && identicalExpr(info, target, truncation.Lhs[0]) && identicalExpr(info, target, unconvert(info, sliceExpr.X)) {
+				return k
+			}
+		}
+		mentioned := false
+		ast.Inspect(stmts[k], func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && info.ObjectOf(ident) == rootObj {
+				mentioned = true
+			}
+			return !mentioned
+		})
+		if mentioned {
+			return -1
+		}
+	}
+	return -1
+}
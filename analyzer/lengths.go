@@ -0,0 +1,300 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+)
+
+// maxConstLen returns the largest length that target may hold, if target is a local slice variable whose every
+// assignment in the enclosing function gives it a constant length: make([]T, n) for a constant n, a composite literal,
+// nil, a zero-length reslice of itself, a refill of itself with append(target[:0], ...) from a known number of elements,
+// or slices.Delete on itself, which never makes it longer.
+// Anything else, including taking the address of the variable, makes the length unknown.
+func (c *checker) maxConstLen(target ast.Expr) (int64, bool) {
+	info := c.pass.TypesInfo
+
+	ident, ok := ast.Unparen(target).(*ast.Ident)
+	if !ok || c.funcDecl == nil || c.funcDecl.Body == nil {
+		return 0, false
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	if !ok || !c.isBodyLocal(v) {
+		// Parameters, results (of function literals too) and variables of outer scopes can hold any length.
+		return 0, false
+	}
+
+	var maxLen int64
+	known := true
+	record := func(value ast.Expr) {
+		n, ok := c.constLenOf(value, v)
+		if !ok {
+			known = false
+		}
+		maxLen = max(maxLen, n)
+	}
+	isVar := func(expr ast.Expr) bool {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		return ok && info.ObjectOf(ident) == v
+	}
+	ast.Inspect(c.funcDecl.Body, func(n ast.Node) bool {
+		if !known {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for j, lhs := range n.Lhs {
+				if !isVar(lhs) {
+					continue
+				}
+				if len(n.Lhs) != len(n.Rhs) || (n.Tok != token.ASSIGN && n.Tok != token.DEFINE) {
+					known = false
+					return false
+				}
+				record(n.Rhs[j])
+			}
+		case *ast.ValueSpec:
+			for j, name := range n.Names {
+				if info.Defs[name] != v {
+					continue
+				}
+				if len(n.Values) == 0 {
+					continue // The zero value, a nil slice.
+				}
+				if len(n.Values) != len(n.Names) {
+					known = false
+					return false
+				}
+				record(n.Values[j])
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && isVar(n.X) {
+				known = false
+				return false
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN && ((n.Key != nil && isVar(n.Key)) || (n.Value != nil && isVar(n.Value))) {
+				known = false
+				return false
+			}
+		}
+		return true
+	})
+	return maxLen, known
+}
+
+// constLenOf returns the length of value, if it is constant, when assigned to the variable v.
+func (c *checker) constLenOf(value ast.Expr, v *types.Var) (int64, bool) {
+	info := c.pass.TypesInfo
+	switch value := ast.Unparen(value).(type) {
+	case *ast.Ident:
+		if _, isNil := info.Uses[value].(*types.Nil); isNil {
+			return 0, true
+		}
+	case *ast.CompositeLit:
+		return compositeLen(value)
+	case *ast.SliceExpr:
+		if ident, ok := ast.Unparen(value.X).(*ast.Ident); ok && info.Uses[ident] == v && c.isZeroLength(value) {
+			return 0, true
+		}
+	case *ast.CallExpr:
+		// slices.Delete(v, i, j) never makes v longer than it already was.
+		if fn := packageFunc(info, value); fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == "slices" && fn.Name() == "Delete" {
+			if ident, ok := ast.Unparen(value.Args[0]).(*ast.Ident); ok && info.Uses[ident] == v {
+				return 0, true
+			}
+			return 0, false
+		}
+		fn, ok := ast.Unparen(value.Fun).(*ast.Ident)
+		if !ok {
+			return 0, false
+		}
+		b, ok := info.Uses[fn].(*types.Builtin)
+		if !ok {
+			return 0, false
+		}
+		switch b.Name() {
+		case "make":
+			if len(value.Args) < 2 {
+				return 0, false
+			}
+			return constInt(info, value.Args[1])
+		case "append":
+			head, ok := ast.Unparen(value.Args[0]).(*ast.SliceExpr)
+			if !ok || !c.isZeroLength(head) {
+				return 0, false
+			}
+			if ident, ok := ast.Unparen(head.X).(*ast.Ident); !ok || info.Uses[ident] != v {
+				return 0, false
+			}
+			return appendedLen(value)
+		}
+	}
+	return 0, false
+}
+
+// appendedLen returns the number of elements appended by the append call, if it is known.
+func appendedLen(call *ast.CallExpr) (int64, bool) {
+	if !call.Ellipsis.IsValid() {
+		return int64(len(call.Args) - 1), true
+	}
+	if len(call.Args) != 2 {
+		return 0, false
+	}
+	lit, ok := ast.Unparen(call.Args[1]).(*ast.CompositeLit)
+	if !ok {
+		return 0, false
+	}
+	return compositeLen(lit)
+}
+
+// compositeLen returns the length of a slice literal, if it has no keyed elements.
+func compositeLen(lit *ast.CompositeLit) (int64, bool) {
+	for _, elt := range lit.Elts {
+		if _, isKeyed := elt.(*ast.KeyValueExpr); isKeyed {
+			return 0, false
+		}
+	}
+	return int64(len(lit.Elts)), true
+}
+
+// constInt returns the value of expr if it is an integer constant.
+func constInt(info *types.Info, expr ast.Expr) (int64, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil {
+		return 0, false
+	}
+	return constant.Int64Val(constant.ToInt(tv.Value))
+}
+
+// zeroedByIndex reports whether the statements of the list before assignStmt zero every element of target one by one,
+// in single or tuple assignments, as for a tiny scratch slice:
+//
+//	s := make([]*Node, 2)
+//	...
+//	s[0], s[1] = nil, nil
+//	s = s[:0]
+//
+// The length of target must be bounded by constants (see maxConstLen), and each index from 0 up to that bound must be
+// assigned the zero value with a constant index. The backward scan stops at the first other statement mentioning target.
+func (c *checker) zeroedByIndex(assignStmt *ast.AssignStmt, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	if assignStmt != c.listStmt {
+		return false
+	}
+	ident, ok := ast.Unparen(target).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	if !ok {
+		return false
+	}
+	maxLen, known := c.maxConstLen(target)
+	if !known || maxLen == 0 {
+		return false
+	}
+
+	zeroed := make(map[int64]bool)
+	// zeroes records the indexes stmt zeroes, and reports whether it does nothing else to target.
+	zeroes := func(stmt ast.Stmt) bool {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != len(assign.Rhs) {
+			return false
+		}
+		indexes := make([]int64, 0, len(assign.Lhs))
+		for j, lhs := range assign.Lhs {
+			index, ok := ast.Unparen(lhs).(*ast.IndexExpr)
+			if !ok || !isVar(info, index.X, v) || !(isZeroValue(info, assign.Rhs[j]) || c.isZeroVar(assign.Rhs[j])) {
+				return false
+			}
+			i, ok := constInt(info, index.Index)
+			if !ok {
+				return false
+			}
+			indexes = append(indexes, i)
+		}
+		for _, i := range indexes {
+			zeroed[i] = true
+		}
+		return true
+	}
+
+	stmts := listStmts(c.listCursor.Node())
+	k := slices.IndexFunc(stmts, func(s ast.Stmt) bool { return s == assignStmt })
+	for k--; k >= 0; k-- {
+		if zeroes(stmts[k]) {
+			continue
+		}
+		if mentionsVar(info, stmts[k], v) {
+			break
+		}
+	}
+	for i := range maxLen {
+		if !zeroed[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isProvablyEmpty reports whether target is a local slice variable that never holds any element, so that a
+// truncation of it has nothing to clear: every assignment gives it length zero (see maxConstLen), as with
+// `var s []*T`, `s := []*T{}` or `s := make([]*T, 0, 16)`, and it is only ever used in ways that cannot write
+// into its backing array: assigned to, resliced to zero length, passed to len or cap, indexed for reading,
+// or ranged over. Any other use, such as passing it to a function, appending to it, or copying it, makes the
+// analysis give up. The check does not follow control flow, so it holds on every path, around loops included.
+func (c *checker) isProvablyEmpty(target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	ident, ok := ast.Unparen(target).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	if !ok {
+		return false
+	}
+	if maxLen, known := c.maxConstLen(target); !known || maxLen != 0 {
+		return false
+	}
+
+	allowed := make(map[*ast.Ident]bool)
+	allow := func(expr ast.Expr) {
+		if ident, ok := ast.Unparen(expr).(*ast.Ident); ok && info.Uses[ident] == v {
+			allowed[ident] = true
+		}
+	}
+	written := make(map[ast.Expr]bool)
+	ast.Inspect(c.funcDecl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				allow(lhs)
+				written[ast.Unparen(lhs)] = true
+			}
+		case *ast.SliceExpr:
+			if c.isZeroLength(n) {
+				allow(n.X)
+			}
+		case *ast.CallExpr:
+			if (isBuiltinCall(info, n, "len") || isBuiltinCall(info, n, "cap")) && len(n.Args) == 1 {
+				allow(n.Args[0])
+			}
+		case *ast.IndexExpr:
+			if !written[n] {
+				allow(n.X)
+			}
+		case *ast.RangeStmt:
+			allow(n.X)
+		}
+		return true
+	})
+	for use, obj := range info.Uses {
+		if obj == v && !allowed[use] {
+			return false
+		}
+	}
+	return true
+}
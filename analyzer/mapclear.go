@@ -0,0 +1,123 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// MapClearDoc is the documentation for the mapclear analyzer.
+const MapClearDoc = `mapclear detects range loops that delete every key of a map, for k := range m { delete(m, k) },
+and suggests the clear built-in (Go 1.21+) instead, which says the same in one call.`
+
+// categoryMapClear is the category of the mapclear analyzer.
+const categoryMapClear = "map-clear"
+
+// NewMapClearAnalyzer returns a new instance of the mapclear analyzer, a companion of clearslice
+// that can be enabled separately under its own name.
+func NewMapClearAnalyzer() *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:       "mapclear",
+		Doc:        MapClearDoc,
+		Requires:   []*analysis.Analyzer{inspect.Analyzer},
+		Run:        runMapClear,
+		ResultType: reflect.TypeOf(new(Result)),
+	}
+}
+
+// runMapClear executes the mapclear analyzer.
+func runMapClear(pass *analysis.Pass) (interface{}, error) {
+	pass, result, err := withSuppressions(pass, nil)
+	if err != nil {
+		return nil, err
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	info := pass.TypesInfo
+	// Only the Go version of files is needed, which takes no configuration.
+	c := &checker{pass: pass}
+
+	for cur := range inspect.Root().Preorder((*ast.RangeStmt)(nil)) {
+		rangeStmt := cur.Node().(*ast.RangeStmt)
+
+		// for k := range m (or for k = range m) without a value variable.
+		key, ok := rangeStmt.Key.(*ast.Ident)
+		if !ok || key.Name == "_" || rangeStmt.Value != nil {
+			continue
+		}
+		if _, isMap := info.TypeOf(rangeStmt.X).Underlying().(*types.Map); !isMap {
+			continue
+		}
+		// As with -modernize-clear, files predating the clear built-in are skipped.
+		if !c.goVersionAtLeast(rangeStmt.Pos(), "go1.21") {
+			continue
+		}
+		// The body is exactly delete(m, k).
+		if len(rangeStmt.Body.List) != 1 {
+			continue
+		}
+		exprStmt, ok := rangeStmt.Body.List[0].(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		call, ok := ast.Unparen(exprStmt.X).(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 || !isBuiltinCall(info, call, "delete") {
+			continue
+		}
+		deleted, ok := ast.Unparen(call.Args[1]).(*ast.Ident)
+		if !ok || info.ObjectOf(deleted) != info.ObjectOf(key) || !identicalExpr(info, rangeStmt.X, call.Args[0]) {
+			continue
+		}
+
+		name, nameable := selectorName(rangeStmt.X)
+		if !nameable {
+			name = c.sourceOf(rangeStmt.X)
+		}
+		diagnostic := analysis.Diagnostic{
+			Pos:      rangeStmt.Pos(),
+			End:      rangeStmt.End(),
+			Category: categoryMapClear,
+			Message:  "range loop deleting every key of map " + name + " can be replaced with clear(" + name + ")",
+		}
+		// The map is evaluated again by the replacement, so it must be a plain name. A key variable declared outside
+		// the loop might become unused. A label stays on the replacement, where the goto statements using it (the only
+		// ones that can, as the body holds no branch) still find it.
+		if nameable && rangeStmt.Tok == token.DEFINE {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+				classify(fixSafe, analysis.SuggestedFix{
+					Message: "Replace the loop with clear(" + name + ").",
+					TextEdits: []analysis.TextEdit{
+						{Pos: rangeStmt.Pos(), End: rangeStmt.End(), NewText: []byte(clearMapText(pass, rangeStmt, name))},
+					},
+				}),
+			}
+		}
+		pass.Report(diagnostic)
+	}
+
+	return result, nil
+}
+
+// clearMapText returns clear(name), which replaces rangeStmt, preceded by the comments of the loop body on lines
+// of their own. A comment on the line of the loop header stays at the end of that line, after clear(name).
+func clearMapText(pass *analysis.Pass, rangeStmt *ast.RangeStmt, name string) string {
+	line := func(pos token.Pos) int { return pass.Fset.Position(pos).Line }
+	var trailing []string
+	body := rangeStmt.Pos()
+	for _, comment := range commentsIn(pass, rangeStmt.Pos(), rangeStmt.End()) {
+		if line(comment.Pos()) == line(rangeStmt.Pos()) {
+			trailing = append(trailing, comment.Text)
+			body = comment.End()
+		}
+	}
+	text := keepStmtComments(pass, body, rangeStmt.End(), "clear("+name+")")
+	if trailing != nil {
+		text += " " + strings.Join(trailing, " ")
+	}
+	return text
+}
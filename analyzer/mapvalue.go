@@ -0,0 +1,120 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkMapValueRoundTrip recognizes the read-modify-write idiom for truncating a map value starting at stmts[i]:
+//
+//	v := m[k]
+//	v = v[:0]
+//	m[k] = v
+//
+// Map values are not addressable, so the write-back is what actually truncates m[k]; the idiom is reported once,
+// at the truncation, naming m[k] rather than the local copy. A clear(v) between the read and the truncation
+// clears the shared backing array and makes the idiom safe. Sequences interleaved with other statements are not matched.
+func (c *checker) checkMapValueRoundTrip(stmts []ast.Stmt, i int) {
+	pass := c.pass
+
+	// v := m[k]
+	read, ok := stmts[i].(*ast.AssignStmt)
+	if !ok || len(read.Lhs) != 1 || len(read.Rhs) != 1 {
+		return
+	}
+	local, ok := read.Lhs[0].(*ast.Ident)
+	if !ok {
+		return
+	}
+	mapValue, ok := ast.Unparen(read.Rhs[0]).(*ast.IndexExpr)
+	if !ok {
+		return
+	}
+	if _, isMap := pass.TypesInfo.TypeOf(mapValue.X).Underlying().(*types.Map); !isMap {
+		return
+	}
+
+	// An optional clear(v) before the truncation.
+	next := i + 1
+	cleared := next < len(stmts) && c.isClearOf(stmts[next], local)
+	if cleared {
+		next++
+	}
+
+	// v = v[:0]
+	if next+1 >= len(stmts) {
+		return
+	}
+	truncation, ok := stmts[next].(*ast.AssignStmt)
+	if !ok || truncation.Tok != token.ASSIGN || len(truncation.Lhs) != 1 || len(truncation.Rhs) != 1 {
+		return
+	}
+	sliceExpr, ok := ast.Unparen(truncation.Rhs[0]).(*ast.SliceExpr)
+	if !ok || !identicalExpr(pass.TypesInfo, local, truncation.Lhs[0]) || !identicalExpr(pass.TypesInfo, local, sliceExpr.X) {
+		return
+	}
+	if !c.isZeroLength(sliceExpr) || sliceExpr.Slice3 {
+		return
+	}
+
+	// m[k] = v
+	writeBack, ok := stmts[next+1].(*ast.AssignStmt)
+	if !ok || writeBack.Tok != token.ASSIGN || len(writeBack.Lhs) != 1 || len(writeBack.Rhs) != 1 {
+		return
+	}
+	if !identicalExpr(pass.TypesInfo, mapValue, writeBack.Lhs[0]) || !identicalExpr(pass.TypesInfo, local, writeBack.Rhs[0]) {
+		return
+	}
+
+	// From here on the truncation belongs to the idiom, whether or not it is reported.
+	c.handled[truncation] = true
+	if cleared {
+		return
+	}
+	elemType, ok := c.referenceElem(local)
+	if !ok {
+		return
+	}
+
+	name := c.render(mapValue)
+	diagnostic := analysis.Diagnostic{
+		Pos:      truncation.Pos(),
+		End:      truncation.End(),
+		Category: categoryTruncation,
+		Message:  "slice " + name + " of type " + elemType.String() + " is resized to zero length through " + local.Name + " without clearing elements",
+	}
+	// The whole sequence collapses into a single statement, which is only possible when the local copy
+	// is introduced by the sequence and not used anywhere else, and the key can be evaluated repeatedly.
+	pkg, imports, hasSlices := c.slicesName(read.Pos())
+	if hasSlices && c.deleteClears(read.Pos()) && read.Tok == token.DEFINE && isPure(pass.TypesInfo, mapValue.Index) && !usedOutside(pass.TypesInfo, pass.TypesInfo.Defs[local], read.Pos(), writeBack.End()) {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(c.safetyOfReplacing(read.Pos(), writeBack.End(), mapValue), analysis.SuggestedFix{
+				Message: "Replace with slices.Delete on the map value to clear elements before len adjustment.",
+				TextEdits: append([]analysis.TextEdit{
+					{
+						Pos:     read.Pos(),
+						End:     writeBack.End(),
+						NewText: []byte(keepStmtComments(pass, read.Pos(), writeBack.End(), name+" = "+pkg+".Delete("+name+", 0, len("+name+"))")),
+					},
+				}, imports...),
+			}),
+		}
+	}
+	pass.Report(diagnostic)
+}
+
+// usedOutside reports whether obj is referenced anywhere outside the source range [from, to).
+func usedOutside(info *types.Info, obj types.Object, from, to token.Pos) bool {
+	if obj == nil {
+		return true
+	}
+	for ident, used := range info.Uses {
+		if used == obj && (ident.Pos() < from || ident.Pos() >= to) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkZeroingLoops reports range loops over a slice whose body only assigns the zero value to the current element,
+// `for i := range s { s[i] = nil }`, which the clear built-in does in one call since Go 1.21. Files compiled for
+// an older Go version are skipped. The fix replaces the loop with clear(s).
+func (c *checker) checkZeroingLoops(inspect *inspector.Inspector) {
+	info := c.pass.TypesInfo
+	for cur := range inspect.Root().Preorder((*ast.RangeStmt)(nil)) {
+		rangeStmt := cur.Node().(*ast.RangeStmt)
+		if _, isSlice := info.TypeOf(rangeStmt.X).Underlying().(*types.Slice); !isSlice {
+			continue // clear deletes the keys of a map rather than zeroing its values, and does not accept arrays.
+		}
+		if !c.isZeroingLoop(rangeStmt) || !c.goVersionAtLeast(rangeStmt.Pos(), "go1.21") {
+			continue
+		}
+		name, nameable := selectorName(rangeStmt.X)
+		if !nameable {
+			name = c.sourceOf(rangeStmt.X)
+		}
+
+		diagnostic := analysis.Diagnostic{
+			Pos:      rangeStmt.Pos(),
+			End:      rangeStmt.End(),
+			Category: categoryModernizeClear,
+			Message:  "range loop zeroing every element of slice " + name + " can be replaced with clear(" + name + ")",
+		}
+		// A labeled loop or a key variable declared outside the loop has no replacement.
+		_, labeled := cur.Parent().Node().(*ast.LabeledStmt)
+		if nameable && !labeled && rangeStmt.Tok == token.DEFINE {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+				classify(fixSafe, analysis.SuggestedFix{
+					Message: "Replace the loop with clear(" + name + ").",
+					TextEdits: []analysis.TextEdit{
+						{Pos: rangeStmt.Pos(), End: rangeStmt.End(), NewText: []byte(keepStmtComments(c.pass, rangeStmt.Pos(), rangeStmt.End(), "clear("+name+")"))},
+					},
+				}),
+			}
+		}
+		c.pass.Report(diagnostic)
+	}
+}
@@ -0,0 +1,75 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+// movedBefore reports whether the elements of target were just moved into another slice, in assume-move mode:
+//
+//	dst = append(dst, batch...)
+//	batch = batch[:0]
+//
+// The elements stay reachable through dst whether or not batch clears them. The append must precede the truncation
+// in the same statement list, append all of target (not a reslice of it), and no statement in between may mention
+// target. Only local variables qualify: a variable also stored in a field, an element or through a pointer retains
+// its backing array beyond the function, and so do fields themselves.
+func (c *checker) movedBefore(assignStmt *ast.AssignStmt, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	if assignStmt != c.listStmt || c.funcDecl == nil || c.funcDecl.Body == nil {
+		return false
+	}
+	ident, ok := ast.Unparen(target).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	if !ok || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() || storedElsewhere(info, c.funcDecl.Body, v) {
+		return false
+	}
+
+	stmts := listStmts(c.listCursor.Node())
+	k := slices.IndexFunc(stmts, func(s ast.Stmt) bool { return s == assignStmt })
+	for k--; k >= 0; k-- {
+		if isMoveOf(info, stmts[k], v) {
+			return true
+		}
+		if mentionsVar(info, stmts[k], v) {
+			return false
+		}
+	}
+	return false
+}
+
+// isMoveOf reports whether stmt appends all the elements of v to another slice, as in dst = append(dst, v...).
+func isMoveOf(info *types.Info, stmt ast.Stmt, v *types.Var) bool {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || isVar(info, assign.Lhs[0], v) {
+		return false
+	}
+	call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok || !call.Ellipsis.IsValid() || len(call.Args) != 2 || !isBuiltinCall(info, call, "append") {
+		return false
+	}
+	return isVar(info, call.Args[1], v) && !mentionsVar(info, call.Args[0], v)
+}
+
+// storedElsewhere reports whether body assigns v itself to anything but a plain variable, such as a field,
+// an element, or the target of a pointer.
+func storedElsewhere(info *types.Info, body *ast.BlockStmt, v *types.Var) bool {
+	stored := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return !stored
+		}
+		for j, lhs := range assign.Lhs {
+			if _, isIdent := ast.Unparen(lhs).(*ast.Ident); !isIdent && isVar(info, assign.Rhs[j], v) {
+				stored = true
+			}
+		}
+		return !stored
+	})
+	return stored
+}
@@ -0,0 +1,107 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// lineRange is the range of lines a suppression directive applies to, starting at the line of the comment.
+type lineRange struct {
+	from, to int
+	// column is the column of the comment, which the node it extends to must start at.
+	column int
+}
+
+// contains reports whether line is in the range.
+func (r lineRange) contains(line int) bool {
+	return r.from <= line && line <= r.to
+}
+
+// directiveRange returns the range of the directive comment, covering its own line only.
+func directiveRange(fset *token.FileSet, comment *ast.Comment) lineRange {
+	pos := fset.Position(comment.Slash)
+	return lineRange{pos.Line, pos.Line, pos.Column}
+}
+
+// expandRanges extends each range to the last line of the nodes of file starting on the line after its comment in
+// the same column, as golangci-lint does for //nolint: a directive on a line of its own applies to the statement or
+// declaration that follows it. ranges holds pointers so the callers' ranges are updated in place.
+func expandRanges(fset *token.FileSet, file *ast.File, ranges []*lineRange) {
+	if len(ranges) == 0 {
+		return
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		start := fset.Position(n.Pos())
+		for _, r := range ranges {
+			if r.from == start.Line-1 && r.column == start.Column {
+				r.to = max(r.to, fset.Position(n.End()).Line)
+			}
+		}
+		return true
+	})
+}
+
+// nolintRange is the range of lines a //nolint directive applies to, and the linters it names.
+// An empty list of names applies to all linters.
+type nolintRange struct {
+	lineRange
+	names []string
+	text  string
+}
+
+// matches reports whether the directive applies to the linter name.
+func (r *nolintRange) matches(name string) bool {
+	if len(r.names) == 0 {
+		return true
+	}
+	for _, n := range r.names {
+		if strings.EqualFold(n, name) || strings.EqualFold(n, "all") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNolint returns the linter names of a //nolint comment, with ok set if text is one at all. It accepts the
+// forms golangci-lint does: a bare //nolint, optionally followed by an explanation after a space, or
+// //nolint:a,b naming linters.
+func parseNolint(text string) (names []string, ok bool) {
+	rest, found := strings.CutPrefix(text, "//nolint")
+	if !found {
+		return nil, false
+	}
+	switch {
+	case rest == "" || rest[0] == ' ':
+		return nil, true
+	case rest[0] == ':':
+		list, _, _ := strings.Cut(rest[1:], " ")
+		for _, name := range strings.Split(list, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		return names, len(names) > 0
+	}
+	return nil, false
+}
+
+// nolintRanges returns the ranges of lines the //nolint directives of file apply to (see expandRanges).
+func nolintRanges(fset *token.FileSet, file *ast.File) []*nolintRange {
+	var ranges []*nolintRange
+	var lines []*lineRange
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if names, ok := parseNolint(comment.Text); ok {
+				r := &nolintRange{directiveRange(fset, comment), names, comment.Text}
+				ranges = append(ranges, r)
+				lines = append(lines, &r.lineRange)
+			}
+		}
+	}
+	expandRanges(fset, file, lines)
+	return ranges
+}
@@ -0,0 +1,344 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// deadParamCopy returns the parameter truncated by assignStmt if its new value can never be observed: target is a
+// parameter of the enclosing function declaration that is not used after assignStmt, not used at all in a loop
+// containing assignStmt, not captured by a closure, and whose address is never taken. It returns nil otherwise.
+// Since the caller's slice keeps its length and elements, such a truncation almost certainly was meant for the caller.
+func (c *checker) deadParamCopy(assignStmt *ast.AssignStmt, target ast.Expr) *types.Var {
+	info := c.pass.TypesInfo
+	ident, ok := ast.Unparen(target).(*ast.Ident)
+	if !ok || c.funcDecl == nil || c.funcDecl.Body == nil {
+		return nil
+	}
+	param, ok := info.Uses[ident].(*types.Var)
+	if !ok || !isParamOf(info, c.funcDecl.Type, param) || !c.unusedAfter(assignStmt, param) {
+		return nil
+	}
+	return param
+}
+
+// deadReceiverCopy returns the value receiver of the enclosing method if target is a field of it, reached without
+// going through a pointer, as in `b.items` in `func (b Buffer) Reset()`, and the receiver is not used after
+// assignStmt in the sense of deadParamCopy. It returns nil otherwise. The truncation only changes the method's
+// copy of the receiver, so the caller's slice keeps its length and elements.
+func (c *checker) deadReceiverCopy(assignStmt *ast.AssignStmt, target ast.Expr) *types.Var {
+	info := c.pass.TypesInfo
+	if c.funcDecl == nil || c.funcDecl.Recv == nil || c.funcDecl.Body == nil {
+		return nil
+	}
+	expr := ast.Unparen(target)
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	for ok {
+		selection, found := info.Selections[sel]
+		if !found || selection.Kind() != types.FieldVal || selection.Indirect() {
+			return nil
+		}
+		expr = ast.Unparen(sel.X)
+		sel, ok = expr.(*ast.SelectorExpr)
+	}
+	root, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	recv, ok := info.Uses[root].(*types.Var)
+	if !ok || !isRecvOf(info, c.funcDecl, recv) {
+		return nil
+	}
+	if _, isPointer := recv.Type().Underlying().(*types.Pointer); isPointer || !c.unusedAfter(assignStmt, recv) {
+		return nil
+	}
+	return recv
+}
+
+// isDeadLocalStore reports whether target is a local variable of the enclosing function whose value after
+// assignStmt can never be observed, in the sense of unusedAfter. Once the function returns, the backing array of
+// such a variable is unreachable along with its elements, so clearing them first gains nothing. Parameters and
+// results, including those of function literals, are excluded, as are fields and package-level variables.
+func (c *checker) isDeadLocalStore(assignStmt *ast.AssignStmt, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	ident, ok := ast.Unparen(target).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	return ok && c.isBodyLocal(v) && c.unusedAfter(assignStmt, v)
+}
+
+// isBodyLocal reports whether v is declared in the body of the enclosing function declaration, other than as a
+// parameter or result of a function literal.
+func (c *checker) isBodyLocal(v *types.Var) bool {
+	info := c.pass.TypesInfo
+	if c.funcDecl == nil || c.funcDecl.Body == nil || v.Pos() < c.funcDecl.Body.Pos() || v.Pos() >= c.funcDecl.Body.End() {
+		return false
+	}
+	isParam := false
+	ast.Inspect(c.funcDecl.Body, func(n ast.Node) bool {
+		if funcType, ok := n.(*ast.FuncType); ok && info.Scopes[funcType] == v.Parent() {
+			isParam = true
+		}
+		return !isParam
+	})
+	return !isParam
+}
+
+// unusedAfter reports whether the value v holds after assignStmt can never be observed: v is not used after
+// assignStmt, not used at all in a loop containing assignStmt, not captured by a closure, and its address is never taken.
+func (c *checker) unusedAfter(assignStmt *ast.AssignStmt, v *types.Var) bool {
+	return c.unusedAfterIn(c.funcDecl.Body, assignStmt, v)
+}
+
+// unusedAfterIn is unusedAfter for a variable scoped to body, such as the value variable of a range statement:
+// only the loops within body can observe the new value in a later iteration.
+func (c *checker) unusedAfterIn(body ast.Node, assignStmt *ast.AssignStmt, v *types.Var) bool {
+	info := c.pass.TypesInfo
+
+	// The innermost loop containing the truncation, whose next iteration could observe the new value.
+	var loop ast.Node
+	dead := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		if !dead || n == nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			if n.Pos() <= assignStmt.Pos() && assignStmt.End() <= n.End() {
+				loop = n
+			}
+		case *ast.FuncLit:
+			if mentionsVar(info, n.Body, v) {
+				dead = false
+			}
+			return false
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && mentionsVar(info, n.X, v) {
+				dead = false
+			}
+		}
+		return true
+	})
+	if !dead {
+		return false
+	}
+	for use, obj := range info.Uses {
+		if obj != v || (assignStmt.Pos() <= use.Pos() && use.Pos() < assignStmt.End()) {
+			continue
+		}
+		if use.Pos() >= assignStmt.End() || (loop != nil && loop.Pos() <= use.Pos() && use.Pos() < loop.End()) {
+			return false
+		}
+	}
+	return true
+}
+
+// isParamOf reports whether v is declared in the parameter list of funcType.
+func isParamOf(info *types.Info, funcType *ast.FuncType, v *types.Var) bool {
+	if funcType.Params == nil {
+		return false
+	}
+	for _, field := range funcType.Params.List {
+		for _, name := range field.Names {
+			if info.Defs[name] == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isRecvOf reports whether v is the receiver of the method fn.
+func isRecvOf(info *types.Info, fn *ast.FuncDecl, v *types.Var) bool {
+	if fn.Recv == nil {
+		return false
+	}
+	for _, field := range fn.Recv.List {
+		for _, name := range field.Names {
+			if info.Defs[name] == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mentionsVar reports whether node refers to v.
+func mentionsVar(info *types.Info, node ast.Node, v *types.Var) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && info.Uses[ident] == v {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// reportParamCopy reports the truncation of the j-th LHS of assignStmt, a copy of the caller's slice in param.
+// There is no fix, since the remedy depends on what the caller expects: returning the slice, taking a pointer, or clearing it.
+func (c *checker) reportParamCopy(assignStmt *ast.AssignStmt, j int, param *types.Var) {
+	startPos, endPos := assignStmt.Pos(), assignStmt.End()
+	if len(assignStmt.Lhs) > 1 {
+		startPos, endPos = assignStmt.Rhs[j].Pos(), assignStmt.Rhs[j].End()
+	}
+	c.pass.Report(analysis.Diagnostic{
+		Pos:      startPos,
+		End:      endPos,
+		Category: categoryParamCopy,
+		Message: "truncation of parameter copy does not affect the caller: " + param.Name() + " is a by-value parameter of " +
+			c.funcDecl.Name.Name + " and its new value is never used",
+	})
+}
+
+// reportReceiverCopy reports the truncation of the j-th LHS of assignStmt, a field of the value receiver recv.
+// The fix turns recv into a pointer receiver, after which the field selections of the body reach the caller's
+// struct as written. It changes the method set of the type, so it is classified for verification, and it is only
+// offered when that keeps the program compiling (see pointerReceiverSafe).
+func (c *checker) reportReceiverCopy(assignStmt *ast.AssignStmt, j int, recv *types.Var) {
+	startPos, endPos := assignStmt.Pos(), assignStmt.End()
+	if len(assignStmt.Lhs) > 1 {
+		startPos, endPos = assignStmt.Rhs[j].Pos(), assignStmt.Rhs[j].End()
+	}
+	diagnostic := analysis.Diagnostic{
+		Pos:      startPos,
+		End:      endPos,
+		Category: categoryReceiverCopy,
+		Message: "truncation of receiver copy does not affect the caller: " + recv.Name() + " is a value receiver of " +
+			c.funcDecl.Name.Name + ", so " + c.sourceOf(ast.Unparen(assignStmt.Lhs[j])) + " only changes its copy of " +
+			types.TypeString(recv.Type(), types.RelativeTo(c.pass.Pkg)) + "; use a pointer receiver",
+	}
+	if c.pointerReceiverSafe(recv) {
+		recvType := c.funcDecl.Recv.List[0].Type
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(fixVerify, analysis.SuggestedFix{
+				Message:   "Change the receiver of " + c.funcDecl.Name.Name + " to a pointer.",
+				TextEdits: []analysis.TextEdit{{Pos: recvType.Pos(), End: recvType.Pos(), NewText: []byte("*")}},
+			}),
+		}
+	}
+	c.pass.Report(diagnostic)
+}
+
+// pointerReceiverSafe reports whether the value receiver recv of the enclosing method can become a pointer receiver.
+// The type must have no other methods with a value receiver, which would be left inconsistent, and its values must not
+// implement an interface declaring the method, in the package, its imports or a type written in the package, as they
+// would no longer. Neither may the values of a type embedding it. Every use of the method in the package must be on an
+// addressable value, or a pointer: a method expression T.Reset, or a call on a map element or a function result, would
+// no longer compile.
+func (c *checker) pointerReceiverSafe(recv *types.Var) bool {
+	info := c.pass.TypesInfo
+	named, ok := types.Unalias(recv.Type()).(*types.Named)
+	if !ok {
+		return false
+	}
+	method, ok := info.Defs[c.funcDecl.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	origin := named.Origin()
+	for i := range origin.NumMethods() {
+		m := origin.Method(i)
+		if _, isPointer := m.Signature().Recv().Type().(*types.Pointer); !isPointer && m != method.Origin() {
+			return false
+		}
+	}
+
+	// The interfaces the values of the type might be used as.
+	var interfaces []*types.Interface
+	addInterfaces := func(scope *types.Scope) {
+		for _, name := range scope.Names() {
+			if typeName, ok := scope.Lookup(name).(*types.TypeName); ok {
+				if iface, ok := typeName.Type().Underlying().(*types.Interface); ok {
+					interfaces = append(interfaces, iface)
+				}
+			}
+		}
+	}
+	addInterfaces(c.pass.Pkg.Scope())
+	for _, imp := range c.pass.Pkg.Imports() {
+		addInterfaces(imp.Scope())
+	}
+	for _, tv := range info.Types {
+		if iface, ok := tv.Type.Underlying().(*types.Interface); ok {
+			interfaces = append(interfaces, iface)
+		}
+	}
+	// The type itself, and the types of the package embedding it, whose value method sets lose the method too.
+	implementers := []types.Type{named}
+	for _, name := range c.pass.Pkg.Scope().Names() {
+		typeName, ok := c.pass.Pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok || typeName.Type() == named {
+			continue
+		}
+		if obj, _, _ := types.LookupFieldOrMethod(typeName.Type(), false, c.pass.Pkg, method.Name()); obj == method {
+			implementers = append(implementers, typeName.Type())
+		}
+	}
+	for _, iface := range interfaces {
+		if obj, _, _ := types.LookupFieldOrMethod(iface, false, c.pass.Pkg, method.Name()); obj == nil {
+			continue
+		}
+		for _, t := range implementers {
+			if types.Implements(t, iface) {
+				return false
+			}
+		}
+	}
+
+	for sel, selection := range info.Selections {
+		if selection.Obj() != method {
+			if fn, ok := selection.Obj().(*types.Func); !ok || fn.Origin() != method.Origin() {
+				continue
+			}
+		}
+		if selection.Kind() == types.MethodExpr {
+			return false
+		}
+		if _, isPointer := selection.Recv().Underlying().(*types.Pointer); !isPointer && !selection.Indirect() && !isAddressable(info, sel.X) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAddressable reports whether expr denotes an addressable value: a variable, a pointer dereference, a field
+// selection of an addressable struct or through a pointer, or an index of a slice or of an addressable array.
+func isAddressable(info *types.Info, expr ast.Expr) bool {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		_, isVar := info.Uses[expr].(*types.Var)
+		return isVar
+	case *ast.StarExpr:
+		return true
+	case *ast.SelectorExpr:
+		selection, ok := info.Selections[expr]
+		if !ok {
+			// A qualified identifier, like pkg.Var.
+			_, isVar := info.Uses[expr.Sel].(*types.Var)
+			return isVar
+		}
+		if _, isPointer := info.TypeOf(expr.X).Underlying().(*types.Pointer); isPointer || selection.Indirect() {
+			return selection.Kind() == types.FieldVal
+		}
+		return selection.Kind() == types.FieldVal && isAddressable(info, expr.X)
+	case *ast.IndexExpr:
+		switch info.TypeOf(expr.X).Underlying().(type) {
+		case *types.Slice:
+			return true
+		case *types.Pointer:
+			return true // an array reached through a pointer
+		case *types.Array:
+			return isAddressable(info, expr.X)
+		}
+	}
+	return false
+}
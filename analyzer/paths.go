@@ -0,0 +1,118 @@
+package clearslice
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+)
+
+// clearedOnAllPaths reports whether every path through the control-flow graph of the enclosing function that reaches
+// assignStmt clears target (in the sense of clears), with no node that may refill it between the clear and assignStmt,
+// as in
+//
+//	if verbose {
+//		log.Println("reset")
+//		clear(s)
+//	} else {
+//		clear(s)
+//	}
+//	s = s[:0]
+//
+// A path that leaves the function, such as an early return, does not reach assignStmt and needs no clear.
+// Paths around a loop are followed back to the loop entry, so a refill later in the loop body counts.
+// cur is the cursor of a node enclosing assignStmt in the same function.
+func (c *checker) clearedOnAllPaths(cur inspector.Cursor, assignStmt *ast.AssignStmt, target ast.Expr) bool {
+	if c.cfgs == nil {
+		return false
+	}
+	var g *cfg.CFG
+	var body *ast.BlockStmt
+	for fn := range cur.Enclosing((*ast.FuncLit)(nil), (*ast.FuncDecl)(nil)) {
+		if lit, ok := fn.Node().(*ast.FuncLit); ok {
+			g, body = c.cfgs.FuncLit(lit), lit.Body
+		} else {
+			decl := fn.Node().(*ast.FuncDecl)
+			g, body = c.cfgs.FuncDecl(decl), decl.Body
+		}
+		break
+	}
+	if g == nil || len(g.Blocks) == 0 {
+		return false
+	}
+
+	// The CFG has no nodes for range statements themselves, only for their operands and bodies,
+	// so a node inside a loop zeroing every element of target stands for the loop.
+	var zeroingLoops []*ast.RangeStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		if loop, ok := n.(*ast.RangeStmt); ok && c.isZeroingLoopOf(loop, target) {
+			zeroingLoops = append(zeroingLoops, loop)
+		}
+		_, isLit := n.(*ast.FuncLit)
+		return !isLit
+	})
+	inZeroingLoop := func(n ast.Node) bool {
+		for _, loop := range zeroingLoops {
+			if loop.Pos() <= n.Pos() && n.End() <= loop.End() {
+				return true
+			}
+		}
+		return false
+	}
+
+	preds := make(map[*cfg.Block][]*cfg.Block)
+	var start *cfg.Block
+	index := -1
+	for _, b := range g.Blocks {
+		// Blocks that cannot be reached, like the one following a return, start no path.
+		if !b.Live {
+			continue
+		}
+		for _, succ := range b.Succs {
+			preds[succ] = append(preds[succ], b)
+		}
+		for k, n := range b.Nodes {
+			if n == assignStmt {
+				start, index = b, k
+			}
+		}
+	}
+	if start == nil {
+		return false
+	}
+
+	// A block is assumed cleared while it is being checked; any path that is not makes the whole check fail.
+	visited := make(map[*cfg.Block]bool)
+	var cleared func(b *cfg.Block, end int) bool
+	cleared = func(b *cfg.Block, end int) bool {
+		if end == len(b.Nodes) {
+			if visited[b] {
+				return true
+			}
+			visited[b] = true
+		}
+		for k := end - 1; k >= 0; k-- {
+			n := b.Nodes[k]
+			if n == assignStmt {
+				// Truncating again refills nothing.
+				continue
+			}
+			if stmt, ok := n.(ast.Stmt); (ok && c.clears(stmt, target)) || inZeroingLoop(n) {
+				return true
+			}
+			if c.mayRefill(n, target) {
+				return false
+			}
+		}
+		if b == g.Blocks[0] {
+			return false
+		}
+		for _, pred := range preds[b] {
+			if !cleared(pred, len(pred.Nodes)) {
+				return false
+			}
+		}
+		return true
+	}
+	return cleared(start, index)
+}
@@ -0,0 +1,308 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkPoolPut reports a call of (*sync.Pool).Put in stmt, either directly or deferred, whose argument is a slice of
+// reference types, a pointer to one (pool.Put(&buf), or pool.Put(bp) for bp := pool.Get().(*[]*T)), or a struct or
+// pointer to a struct with such a slice field (pool.Put(w) for w.buf), if the slice is not cleared earlier in the
+// function. The pooled backing array otherwise keeps the elements reachable until the buffer is reused. Clears are
+// recognized as clear(buf), clear(buf[:cap(buf)]), buf = slices.Delete(buf, 0, len(buf)), and loops zeroing every
+// element, `for i := range buf { buf[i] = nil }`.
+func (c *checker) checkPoolPut(stmt ast.Stmt) {
+	info := c.pass.TypesInfo
+
+	var call *ast.CallExpr
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		call, _ = ast.Unparen(stmt.X).(*ast.CallExpr)
+	case *ast.DeferStmt:
+		call = stmt.Call
+	}
+	if call == nil || len(call.Args) != 1 {
+		return
+	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	if fn, ok := info.Uses[sel.Sel].(*types.Func); !ok || fn.FullName() != "(*sync.Pool).Put" {
+		return
+	}
+
+	arg := ast.Unparen(call.Args[0])
+	if addr, ok := arg.(*ast.UnaryExpr); ok && addr.Op == token.AND {
+		arg = ast.Unparen(addr.X)
+	}
+	if sliceExpr, ok := arg.(*ast.SliceExpr); ok {
+		arg = ast.Unparen(sliceExpr.X)
+	}
+	if _, isLit := arg.(*ast.CompositeLit); isLit {
+		return
+	}
+	argName := c.render(arg)
+	if _, isStar := arg.(*ast.StarExpr); isStar {
+		argName = "(" + argName + ")"
+	}
+
+	// The slices put back: the argument itself, or the slice fields of the struct it is or points to.
+	type pooled struct {
+		name  string
+		full  string     // the slice extended to its capacity
+		field *types.Var // nil for the argument itself
+		deref bool       // whether the argument points to the slice
+		elem  types.Type
+	}
+	var candidates []pooled
+	t := info.TypeOf(arg)
+	ptr, isPointer := t.Underlying().(*types.Pointer)
+	if isPointer {
+		t = ptr.Elem()
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		if !isOrContainsReferenceTypes(u.Elem(), c.generic == genericConservative) {
+			break
+		}
+		name, operand := argName, c.render(arg)
+		if isPointer {
+			name, operand = "(*"+operand+")", "*"+operand
+		}
+		candidates = append(candidates, pooled{name: name, full: name + "[:cap(" + operand + ")]", deref: isPointer, elem: u.Elem()})
+	case *types.Struct:
+		for i := range u.NumFields() {
+			field := u.Field(i)
+			slice, ok := field.Type().Underlying().(*types.Slice)
+			if !ok || !isOrContainsReferenceTypes(slice.Elem(), c.generic == genericConservative) {
+				continue
+			}
+			name := argName + "." + field.Name()
+			candidates = append(candidates, pooled{name: name, full: name + "[:cap(" + name + ")]", field: field, elem: slice.Elem()})
+		}
+	}
+
+	for _, candidate := range candidates {
+		matches := func(expr ast.Expr) bool {
+			if candidate.deref {
+				star, ok := ast.Unparen(expr).(*ast.StarExpr)
+				return ok && c.sameSlice(arg, star.X)
+			}
+			if candidate.field == nil {
+				return c.sameSlice(arg, expr)
+			}
+			root, fields := fieldPath(info, expr)
+			return len(fields) == 1 && fields[0] == candidate.field && identicalExpr(info, arg, root)
+		}
+		if c.clearedBefore(call.Pos(), matches) {
+			continue
+		}
+		diagnostic := analysis.Diagnostic{
+			Pos:      call.Pos(),
+			End:      call.End(),
+			Category: categoryPoolPut,
+			Message: "slice " + candidate.name + " of type " + candidate.elem.String() + " is put into a sync.Pool without clearing elements; " +
+				"the pooled backing array keeps them reachable until it is reused",
+		}
+		// A deferred Put runs later than any inserted statement would, so it gets no fix. The fix names the slice
+		// a second time, so the argument must evaluate to the same slice again, as it does without calls or indexes
+		// by a variable.
+		if _, isExpr := stmt.(*ast.ExprStmt); isExpr && stmt == c.listStmt && isPure(info, arg) {
+			diagnostic.SuggestedFixes = c.poolClearFix(stmt, arg, candidate.name, candidate.full, candidate.elem)
+		}
+		c.pass.Report(diagnostic)
+	}
+}
+
+// poolClearFix returns the fix clearing the slice name, of element type elemType, before the Put statement stmt.
+// The backing array may have been truncated already, so the fix clears full, the slice extended to its capacity.
+// Before Go 1.21, which has no clear built-in, a loop zeroes the elements instead; no fix is returned then if their
+// zero value or a free index variable cannot be spelled.
+func (c *checker) poolClearFix(stmt ast.Stmt, arg ast.Expr, name, full string, elemType types.Type) []analysis.SuggestedFix {
+	pos, indent := stmt.Pos(), c.indentAt(stmt.Pos())
+	fix := analysis.SuggestedFix{Message: "Clear the backing array of " + name + " before putting it into the pool."}
+	text := "clear(" + full + ")\n" + indent
+	if !c.goVersionAtLeast(pos, "go1.21") {
+		zero, imports, ok := c.zeroLiteral(pos, elemType)
+		if !ok {
+			return nil
+		}
+		index, ok := freeIndex(arg)
+		if !ok {
+			return nil
+		}
+		fix.Message = "Zero the backing array of " + name + " in a loop before putting it into the pool."
+		text = "for " + index + " := range " + full + " {\n" + indent + "\t" + full + "[" + index + "] = " + zero + "\n" + indent + "}\n" + indent
+		fix.TextEdits = imports
+	}
+	fix.TextEdits = append([]analysis.TextEdit{{Pos: pos, End: pos, NewText: []byte(text)}}, fix.TextEdits...)
+	return []analysis.SuggestedFix{classify(c.safetyOf(pos, arg), fix)}
+}
+
+// clearedBefore reports whether the enclosing function clears a slice satisfying matches anywhere before pos.
+func (c *checker) clearedBefore(pos token.Pos, matches func(ast.Expr) bool) bool {
+	if c.funcDecl == nil || c.funcDecl.Body == nil {
+		return false
+	}
+	info := c.pass.TypesInfo
+	cleared := false
+	ast.Inspect(c.funcDecl.Body, func(n ast.Node) bool {
+		if cleared || n == nil || n.Pos() >= pos {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			// clear(buf) or clear(buf[:cap(buf)])
+			if !isBuiltinCall(info, n, "clear") || len(n.Args) != 1 {
+				break
+			}
+			arg := n.Args[0]
+			if full := fullCapacityOf(info, arg); full != nil {
+				arg = full
+			}
+			cleared = matches(arg)
+		case *ast.AssignStmt:
+			// buf = slices.Delete(buf, 0, len(buf))
+			if len(n.Lhs) != 1 || len(n.Rhs) != 1 || !matches(n.Lhs[0]) {
+				break
+			}
+			cleared = isFullDelete(info, n.Rhs[0], n.Lhs[0]) && c.deleteClears(n.Pos())
+		case *ast.RangeStmt:
+			// for i := range buf { buf[i] = nil }, also over buf[:cap(buf)]
+			x := n.X
+			if full := fullCapacityOf(info, x); full != nil {
+				x = full
+			}
+			cleared = matches(x) && c.isZeroingLoop(n)
+		}
+		return !cleared
+	})
+	return cleared
+}
+
+// isBuiltinCall reports whether call calls the built-in function name.
+func isBuiltinCall(info *types.Info, call *ast.CallExpr, name string) bool {
+	fn, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := info.Uses[fn].(*types.Builtin)
+	return ok && b.Name() == name
+}
+
+// isFullDeleteOf reports whether stmt is `s = slices.Delete(s, 0, len(s))` for the same slice s as target.
+func (c *checker) isFullDeleteOf(stmt ast.Stmt, target ast.Expr) bool {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return false
+	}
+	return c.sameSlice(target, assign.Lhs[0]) && isFullDelete(c.pass.TypesInfo, assign.Rhs[0], assign.Lhs[0]) && c.deleteClears(stmt.Pos())
+}
+
+// isFullDelete reports whether expr is slices.Delete(target, 0, len(target)), with Delete from the standard
+// library or from golang.org/x/exp/slices, whatever name the package is imported under. The x/exp one calls the
+// standard library one, so both clear the elements under the same condition (see deleteClears).
+func isFullDelete(info *types.Info, expr, target ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 3 {
+		return false
+	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || !isSlicesPackage(fn.Pkg().Path()) || fn.Name() != "Delete" {
+		return false
+	}
+	return identicalExpr(info, target, call.Args[0]) && isZeroConst(info, call.Args[1]) && isLenOf(info, call.Args[2], target)
+}
+
+// isSlicesPackage reports whether path is the import path of the standard slices package or of its predecessor,
+// golang.org/x/exp/slices.
+func isSlicesPackage(path string) bool {
+	return path == "slices" || path == "golang.org/x/exp/slices"
+}
+
+// isZeroingLoop reports whether rangeStmt zeroes every element of the ranged slice: `for i := range s { s[i] = nil }`,
+// also over the full capacity, `for i := range s[:cap(s)] { s[:cap(s)][i] = nil }`.
+// The zero value may also be a constant, an empty composite literal or a provably zero variable (`var zero T`)
+// of the element type, as in s[i] = 0.
+func (c *checker) isZeroingLoop(rangeStmt *ast.RangeStmt) bool {
+	info := c.pass.TypesInfo
+	key, ok := rangeStmt.Key.(*ast.Ident)
+	if !ok || rangeStmt.Value != nil || len(rangeStmt.Body.List) != 1 {
+		return false
+	}
+	assign, ok := rangeStmt.Body.List[0].(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return false
+	}
+	slot, ok := ast.Unparen(assign.Lhs[0]).(*ast.IndexExpr)
+	if !ok {
+		return false
+	}
+	ranged, indexed := rangeStmt.X, slot.X
+	if full := fullCapacityOf(info, ranged); full != nil {
+		if indexed = fullCapacityOf(info, indexed); indexed == nil {
+			return false
+		}
+		ranged = full
+	}
+	if !identicalExpr(info, ranged, indexed) {
+		return false
+	}
+	index, ok := ast.Unparen(slot.Index).(*ast.Ident)
+	if !ok || info.ObjectOf(index) != info.ObjectOf(key) {
+		return false
+	}
+	rhs := assign.Rhs[0]
+	if tv, ok := info.Types[rhs]; ok && tv.IsNil() {
+		return true
+	}
+	// Anything but nil must have the element type: 0 or T{} assigned to an interface element is not its zero value.
+	return (isZeroValue(info, rhs) || isZeroConstant(info, rhs) || c.isZeroVar(rhs)) && types.Identical(info.TypeOf(rhs), info.TypeOf(slot))
+}
+
+// isZeroingLoopOf reports whether stmt is a range loop zeroing every element of the same slice as target.
+func (c *checker) isZeroingLoopOf(stmt ast.Stmt, target ast.Expr) bool {
+	loop, ok := stmt.(*ast.RangeStmt)
+	if !ok || !c.isZeroingLoop(loop) {
+		return false
+	}
+	if full := fullCapacityOf(c.pass.TypesInfo, loop.X); full != nil {
+		return c.sameSlice(target, full)
+	}
+	return c.sameSlice(target, loop.X)
+}
+
+// isZeroVar reports whether expr is a local variable that provably holds its zero value wherever it is used.
+func (c *checker) isZeroVar(expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := c.pass.TypesInfo.Uses[ident].(*types.Var)
+	return ok && c.zeroVars[v]
+}
+
+// isZeroConstant reports whether expr is a constant holding the zero value of its type: 0, "" or false.
+func isZeroConstant(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil {
+		return false
+	}
+	switch tv.Value.Kind() {
+	case constant.String:
+		return constant.StringVal(tv.Value) == ""
+	case constant.Bool:
+		return !constant.BoolVal(tv.Value)
+	default:
+		return isZeroConst(info, expr)
+	}
+}
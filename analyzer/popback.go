@@ -0,0 +1,125 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkPopBack recognizes the stack pop idiom starting at stmts[i]:
+//
+//	x := s[len(s)-1]
+//	s = s[:len(s)-1]
+//
+// optionally preceded by `n := len(s)-1` and using n in place of len(s)-1, or done at once as
+// `x, s = s[len(s)-1], s[:len(s)-1]`. The popped slot beyond the new length keeps referencing the element
+// until it is overwritten by a later push. Zeroing the slot between the read and the truncation makes the idiom safe;
+// this is impossible in the single-statement form, which is therefore reported without a fix.
+// The idiom is reported once, at the truncation, after the swap-remove check had its chance at the same statements.
+func (c *checker) checkPopBack(stmts []ast.Stmt, i int) {
+	info := c.pass.TypesInfo
+
+	// An optional n := len(s)-1.
+	last, next := c.matchLastTemp(stmts[i])
+	next += i
+	if next >= len(stmts) {
+		return
+	}
+	read, ok := stmts[next].(*ast.AssignStmt)
+	if !ok || c.handled[read] {
+		return
+	}
+
+	// x, s = s[len(s)-1], s[:len(s)-1]
+	if len(read.Lhs) == 2 && len(read.Rhs) == 2 && read.Tok == token.ASSIGN {
+		for j := range read.Lhs {
+			popped, ok := ast.Unparen(read.Rhs[1-j]).(*ast.IndexExpr)
+			if !ok || !last.is(popped.Index, popped.X) {
+				continue
+			}
+			target := ast.Unparen(popped.X)
+			sliceExpr, ok := ast.Unparen(read.Rhs[j]).(*ast.SliceExpr)
+			if !ok || !identicalExpr(info, target, read.Lhs[j]) || !c.isShrinkOf(sliceExpr, target, last) {
+				continue
+			}
+			c.handled[read] = true
+			c.reportPopBack(read, target, sliceExpr, read.Lhs[1-j], false)
+			return
+		}
+		return
+	}
+
+	// x := s[len(s)-1]
+	if len(read.Lhs) != 1 || len(read.Rhs) != 1 || next+1 >= len(stmts) {
+		return
+	}
+	popped, ok := ast.Unparen(read.Rhs[0]).(*ast.IndexExpr)
+	if !ok || !last.is(popped.Index, popped.X) {
+		return
+	}
+	target := ast.Unparen(popped.X)
+	if _, isSlice := info.TypeOf(target).Underlying().(*types.Slice); !isSlice {
+		return
+	}
+	next++
+
+	// An optional s[len(s)-1] = nil.
+	assigned, zeroed := c.matchSlotWrite(stmts[next], target, last)
+	if assigned && !zeroed {
+		return
+	}
+	if assigned {
+		next++
+	}
+
+	// s = s[:len(s)-1]
+	if next >= len(stmts) {
+		return
+	}
+	truncation, sliceExpr := c.matchShrink(stmts[next], target, last)
+	if truncation == nil || c.handled[truncation] {
+		return
+	}
+
+	// From here on the truncation belongs to the idiom, whether or not it is reported.
+	c.handled[truncation] = true
+	if !zeroed {
+		c.reportPopBack(truncation, target, sliceExpr, read.Lhs[0], true)
+	}
+}
+
+// reportPopBack reports the truncation of a pop from target into dst. The fix zeroing the popped slot
+// is only offered if the truncation is a statement of its own.
+func (c *checker) reportPopBack(truncation *ast.AssignStmt, target ast.Expr, sliceExpr *ast.SliceExpr, dst ast.Expr, fixable bool) {
+	elemType, ok := c.referenceElem(target)
+	if !ok {
+		return
+	}
+	name, ok := selectorName(target)
+	if !ok {
+		name = c.sourceOf(target)
+	}
+	slot := name + "[" + c.sourceOf(sliceExpr.High) + "]"
+	hint := "set " + slot + " to its zero value before shrinking"
+	if zero, _, ok := c.zeroLiteral(truncation.Pos(), elemType); ok {
+		hint = "set " + slot + " = " + zero + " before shrinking"
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:      truncation.Pos(),
+		End:      truncation.End(),
+		Category: categoryTruncation,
+		Message: "slice " + name + " of type " + elemType.String() + " pops its last element into " + c.sourceOf(dst) +
+			" without clearing the popped slot; " + hint,
+	}
+	if c.inReuseMethod() {
+		diagnostic.Category = categoryReusePoint
+		diagnostic.Message += " (this method is a reuse point; retained elements survive until the next fill)"
+	}
+	if fixable {
+		diagnostic.SuggestedFixes = c.zeroSlotFix(truncation, target, sliceExpr.High, elemType)
+	}
+	c.pass.Report(diagnostic)
+}
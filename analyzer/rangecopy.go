@@ -0,0 +1,156 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// findRangeValues returns the value variables declared by range statements, mapped to their statement.
+func findRangeValues(pass *analysis.Pass, inspect *inspector.Inspector) map[*types.Var]*ast.RangeStmt {
+	rangeValues := make(map[*types.Var]*ast.RangeStmt)
+	inspect.Preorder([]ast.Node{(*ast.RangeStmt)(nil)}, func(n ast.Node) {
+		rangeStmt := n.(*ast.RangeStmt)
+		if rangeStmt.Tok != token.DEFINE {
+			return
+		}
+		if ident, ok := rangeStmt.Value.(*ast.Ident); ok {
+			if v, ok := pass.TypesInfo.Defs[ident].(*types.Var); ok {
+				rangeValues[v] = rangeStmt
+			}
+		}
+	})
+	return rangeValues
+}
+
+// rangeCopyOf returns the range statement declaring expr as its value variable if the value assignStmt stores in
+// it can never be observed, or nil otherwise. The range statement assigns the variable afresh in every iteration,
+// so only the loops within its body count. A truncated copy that is used afterwards, like one appended to and
+// written back to the collection, shares the backing array of the element and is checked as any other slice.
+func (c *checker) rangeCopyOf(assignStmt *ast.AssignStmt, expr ast.Expr) *ast.RangeStmt {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := c.pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok {
+		return nil
+	}
+	rangeStmt := c.rangeValues[v]
+	if rangeStmt == nil || !c.unusedAfterIn(rangeStmt.Body, assignStmt, v) {
+		return nil
+	}
+	return rangeStmt
+}
+
+// reportRangeCopy reports the truncation of the j-th LHS of assignStmt, the value variable of rangeStmt.
+// The slices.Delete fix would be just as ineffective on the copy, so the fix, if any, applies to the element of the
+// collection instead: `table[i] = slices.Delete(table[i], 0, len(table[i]))` for a zero-length truncation where
+// slices.Delete clears, or `table[i] = table[i][:0]` with the bounds as written. It needs a slice or an array to
+// range over; writing back map elements while ranging over the map is left to the author. The element is indexed
+// by the key variable, or by a new one replacing the blank key. The truncation must be the only use of the value
+// variable, which goes away: any other use would still see the untruncated copy.
+func (c *checker) reportRangeCopy(assignStmt *ast.AssignStmt, j int, rangeStmt *ast.RangeStmt, sliceExpr *ast.SliceExpr) {
+	info := c.pass.TypesInfo
+	value := ast.Unparen(assignStmt.Lhs[j]).(*ast.Ident)
+
+	startPos, endPos := assignStmt.Pos(), assignStmt.End()
+	if len(assignStmt.Lhs) > 1 {
+		startPos, endPos = assignStmt.Rhs[j].Pos(), assignStmt.Rhs[j].End()
+	}
+	c.pass.Report(analysis.Diagnostic{
+		Pos:      startPos,
+		End:      endPos,
+		Category: categoryRangeCopy,
+		Message: "assignment to range variable has no effect on the ranged collection: " + value.Name +
+			" is a copy of an element of " + c.sourceOf(rangeStmt.X),
+		SuggestedFixes: c.rangeCopyFixes(assignStmt, j, rangeStmt, sliceExpr, info.Uses[value].(*types.Var)),
+	})
+}
+
+// rangeCopyFixes returns the fix of reportRangeCopy for the truncation of value, or nil if there is none.
+func (c *checker) rangeCopyFixes(assignStmt *ast.AssignStmt, j int, rangeStmt *ast.RangeStmt, sliceExpr *ast.SliceExpr, value *types.Var) []analysis.SuggestedFix {
+	info := c.pass.TypesInfo
+	collection, nameable := selectorName(rangeStmt.X)
+	if _, isMap := info.TypeOf(rangeStmt.X).Underlying().(*types.Map); isMap || !nameable || !c.elementsAssignable(rangeStmt.X) || boundsMention(info, sliceExpr, value) {
+		return nil
+	}
+	key, ok := rangeStmt.Key.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	// Any other use of the value variable would still see the copy, not the truncated element.
+	for use, obj := range info.Uses {
+		if obj == value && use != assignStmt.Lhs[j] && use != ast.Unparen(sliceExpr.X) {
+			return nil
+		}
+	}
+
+	// The value variable goes away, as the truncation was its only use.
+	var edits []analysis.TextEdit
+	index := key.Name
+	if key.Name == "_" {
+		index, ok = freeIndex(rangeStmt)
+		if !ok {
+			return nil
+		}
+		edits = append(edits, analysis.TextEdit{Pos: key.Pos(), End: rangeStmt.Value.End(), NewText: []byte(index)})
+	} else {
+		edits = append(edits, analysis.TextEdit{Pos: key.End(), End: rangeStmt.Value.End()})
+	}
+
+	element := collection + "[" + index + "]"
+	message := "Truncate " + element + " in the collection instead."
+	var replacement string
+	if pkg, imports, ok := c.slicesName(assignStmt.Pos()); ok && c.deleteClears(assignStmt.Pos()) && isZeroLengthSlice(info, sliceExpr) {
+		replacement = pkg + ".Delete(" + element + ", 0, len(" + element + "))"
+		message = "Clear and truncate " + element + " in the collection with slices.Delete instead."
+		edits = append(edits, imports...)
+	} else {
+		bounds, ok := c.readSource(sliceExpr)
+		if !ok {
+			return nil
+		}
+		// Keep the bounds as written: everything after the sliced operand.
+		replacement = element + bounds[sliceExpr.Lbrack-sliceExpr.Pos():]
+	}
+	edits = append(edits,
+		analysis.TextEdit{Pos: assignStmt.Lhs[j].Pos(), End: assignStmt.Lhs[j].End(), NewText: []byte(element)},
+		analysis.TextEdit{Pos: assignStmt.Rhs[j].Pos(), End: assignStmt.Rhs[j].End(), NewText: []byte(replacement)},
+	)
+	return []analysis.SuggestedFix{
+		classify(fixVerify, analysis.SuggestedFix{Message: message, TextEdits: edits}),
+	}
+}
+
+// isZeroLengthSlice reports whether sliceExpr is s[:0] or s[0:0], whose result keeps the full capacity of s.
+func isZeroLengthSlice(info *types.Info, sliceExpr *ast.SliceExpr) bool {
+	return !sliceExpr.Slice3 && (sliceExpr.Low == nil || isZeroConst(info, sliceExpr.Low)) && sliceExpr.High != nil && isZeroConst(info, sliceExpr.High)
+}
+
+// elementsAssignable reports whether collection[key] can be assigned to: collection is a slice, a map,
+// an addressable array variable or field, or a pointer to an array.
+func (c *checker) elementsAssignable(collection ast.Expr) bool {
+	switch t := c.pass.TypesInfo.TypeOf(collection).Underlying().(type) {
+	case *types.Slice, *types.Map, *types.Array:
+		return true
+	case *types.Pointer:
+		_, isArray := t.Elem().Underlying().(*types.Array)
+		return isArray
+	default:
+		return false
+	}
+}
+
+// boundsMention reports whether any index of sliceExpr refers to v.
+func boundsMention(info *types.Info, sliceExpr *ast.SliceExpr, v *types.Var) bool {
+	for _, bound := range []ast.Expr{sliceExpr.Low, sliceExpr.High, sliceExpr.Max} {
+		if bound != nil && mentionsVar(info, bound, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkRealloc reports the j-th LHS/RHS pair of assignStmt if it resets a slice-typed struct field to nil or to
+// an empty composite literal, as in `b.rows = nil` or `b.rows = []*Row{}`, inside a loop or a reuse method.
+// Fields reset there are usually refilled right away, so dropping the backing array only causes allocation churn.
+// The fix keeps the array: it truncates the field, clearing the elements with slices.Delete if they hold references.
+// Resets outside loops, such as one-time initializations, are not reported.
+func (c *checker) checkRealloc(assignStmt *ast.AssignStmt, j int) {
+	info := c.pass.TypesInfo
+
+	if !c.inLoop && !c.inReuseMethod() {
+		return
+	}
+	field, ok := ast.Unparen(assignStmt.Lhs[j]).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	if selection, ok := info.Selections[field]; !ok || selection.Kind() != types.FieldVal {
+		return
+	}
+	name, ok := selectorName(field)
+	if !ok {
+		return
+	}
+	name = c.render(field)
+	slice, ok := info.TypeOf(field).Underlying().(*types.Slice)
+	if !ok {
+		return
+	}
+	rhs := ast.Unparen(assignStmt.Rhs[j])
+	var value string
+	switch rhs := rhs.(type) {
+	case *ast.Ident:
+		if _, isNil := info.Uses[rhs].(*types.Nil); !isNil {
+			return
+		}
+		value = "nil"
+	case *ast.CompositeLit:
+		if len(rhs.Elts) != 0 {
+			return
+		}
+		value = "an empty slice literal"
+	default:
+		return
+	}
+
+	replacement, fixes := c.reuseReplacement(assignStmt.Rhs[j], name, slice, "Truncate the field instead of reallocating it.")
+	where := "inside a loop"
+	if !c.inLoop {
+		where = "inside reuse method " + c.funcDecl.Name.Name
+	}
+	c.pass.Report(analysis.Diagnostic{
+		Pos:      assignStmt.Rhs[j].Pos(),
+		End:      assignStmt.Rhs[j].End(),
+		Category: categoryRealloc,
+		Message: "field " + name + " is reset to " + value + " " + where + ", which discards its backing array; " +
+			"keep the capacity with " + name + " = " + replacement,
+		SuggestedFixes: fixes,
+	})
+}
+
+// checkResetMake reports the j-th LHS/RHS pair of assignStmt if it reallocates a slice-typed struct field with its
+// own capacity inside a reuse method, as in `r.items = make([]*Item, 0, cap(r.items))`. The new array costs an
+// allocation, while the old one keeps its elements alive until it is collected anyway. The fix reuses the array.
+// A make with any other capacity is taken to resize the buffer on purpose.
+func (c *checker) checkResetMake(assignStmt *ast.AssignStmt, j int) {
+	info := c.pass.TypesInfo
+
+	if !c.inReuseMethod() {
+		return
+	}
+	field, ok := ast.Unparen(assignStmt.Lhs[j]).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	if selection, ok := info.Selections[field]; !ok || selection.Kind() != types.FieldVal {
+		return
+	}
+	name, ok := selectorName(field)
+	if !ok {
+		return
+	}
+	name = c.render(field)
+	slice, ok := info.TypeOf(field).Underlying().(*types.Slice)
+	if !ok {
+		return
+	}
+	call, ok := ast.Unparen(assignStmt.Rhs[j]).(*ast.CallExpr)
+	if !ok || len(call.Args) != 3 || !isBuiltinCall(info, call, "make") || !isZeroConst(info, call.Args[1]) {
+		return
+	}
+	capCall, ok := ast.Unparen(call.Args[2]).(*ast.CallExpr)
+	if !ok || len(capCall.Args) != 1 || !isBuiltinCall(info, capCall, "cap") || !identicalExpr(info, field, capCall.Args[0]) {
+		return
+	}
+
+	replacement, fixes := c.reuseReplacement(assignStmt.Rhs[j], name, slice, "Reuse the backing array instead of reallocating it.")
+	c.pass.Report(analysis.Diagnostic{
+		Pos:      assignStmt.Rhs[j].Pos(),
+		End:      assignStmt.Rhs[j].End(),
+		Category: categoryResetMake,
+		Message: "field " + name + " is reallocated with its own capacity in reuse method " + c.funcDecl.Name.Name +
+			", which discards its backing array; reuse it with " + name + " = " + replacement,
+		SuggestedFixes: fixes,
+	})
+}
+
+// reuseReplacement returns the expression emptying the slice name of type slice while keeping its backing array:
+// slices.Delete over the whole slice if the elements hold references, and a plain truncation otherwise.
+// The fix, described by message, replaces expr with it and adds the import of slices where needed; there is none
+// if the file is compiled for a Go version predating slices, or for Go 1.21, whose slices.Delete does not clear
+// and whose replacement carries a note saying so, as the messages end with it.
+func (c *checker) reuseReplacement(expr ast.Expr, name string, slice *types.Slice, message string) (string, []analysis.SuggestedFix) {
+	replacement := name + "[:0]"
+	var imports []analysis.TextEdit
+	if isOrContainsReferenceTypes(slice.Elem(), c.generic == genericConservative) {
+		pkg, edits, ok := c.slicesName(expr.Pos())
+		if !ok {
+			return "slices.Delete(" + name + ", 0, len(" + name + "))", nil
+		}
+		if !c.deleteClears(expr.Pos()) {
+			return pkg + ".Delete(" + name + ", 0, len(" + name + "))" + deleteKeepsTailNote, nil
+		}
+		replacement, imports = pkg+".Delete("+name+", 0, len("+name+"))", edits
+	}
+	return replacement, []analysis.SuggestedFix{
+		classify(fixVerify, analysis.SuggestedFix{
+			Message:   message,
+			TextEdits: append([]analysis.TextEdit{{Pos: expr.Pos(), End: expr.End(), NewText: []byte(keepExprComments(c.pass, expr.Pos(), expr.End(), replacement))}}, imports...),
+		}),
+	}
+}
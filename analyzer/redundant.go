@@ -0,0 +1,103 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkRedundantDelete reports the j-th LHS/RHS pair of assignStmt if it empties a slice whose elements hold
+// no references with `s = slices.Delete(s, 0, len(s))`. Clearing such elements keeps nothing from being collected,
+// so the fix is the plain truncation `s = s[:0]`, which avoids the linear work.
+func (c *checker) checkRedundantDelete(assignStmt *ast.AssignStmt, j int) {
+	info := c.pass.TypesInfo
+
+	target := ast.Unparen(assignStmt.Lhs[j])
+	name, ok := selectorName(target)
+	if !ok || !isFullDelete(info, assignStmt.Rhs[j], target) {
+		return
+	}
+	elemType, ok := c.primitiveElem(target)
+	if !ok {
+		return
+	}
+
+	c.pass.Report(analysis.Diagnostic{
+		Pos:      assignStmt.Rhs[j].Pos(),
+		End:      assignStmt.Rhs[j].End(),
+		Category: categoryRedundantClear,
+		Message: "slices.Delete clears elements of slice " + name + " of type " + elemType.String() +
+			", which hold no references; truncate with " + name + "[:0] instead",
+		SuggestedFixes: []analysis.SuggestedFix{
+			classify(c.safetyOf(assignStmt.Pos(), target), analysis.SuggestedFix{
+				Message: "Replace with a plain truncation.",
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     assignStmt.Rhs[j].Pos(),
+						End:     assignStmt.Rhs[j].End(),
+						NewText: []byte(name + "[:0]"),
+					},
+				},
+			}),
+		},
+	})
+}
+
+// checkRedundantClear reports stmts[i] if it clears a slice whose elements hold no references right before
+// stmts[i+1] truncates it to zero length, as in `clear(s); s = s[:0]` or `clear(s[:cap(s)]); s = s[:0]`.
+// The fix removes the clear.
+func (c *checker) checkRedundantClear(stmts []ast.Stmt, i int) {
+	info := c.pass.TypesInfo
+	if i+1 >= len(stmts) {
+		return
+	}
+	clearStmt := stmts[i]
+	truncation, ok := stmts[i+1].(*ast.AssignStmt)
+	if !ok || truncation.Tok != token.ASSIGN || len(truncation.Lhs) != 1 || len(truncation.Rhs) != 1 {
+		return
+	}
+	sliceExpr, ok := ast.Unparen(truncation.Rhs[0]).(*ast.SliceExpr)
+	if !ok || !c.isZeroLength(sliceExpr) || !identicalExpr(info, truncation.Lhs[0], unconvert(info, sliceExpr.X)) {
+		return
+	}
+	target := ast.Unparen(truncation.Lhs[0])
+	if !c.isClearOf(clearStmt, target) && !c.isClearOfCapacity(clearStmt, target) {
+		return
+	}
+	elemType, ok := c.primitiveElem(target)
+	if !ok {
+		return
+	}
+
+	c.pass.Report(analysis.Diagnostic{
+		Pos:      clearStmt.Pos(),
+		End:      clearStmt.End(),
+		Category: categoryRedundantClear,
+		Message: "clearing slice " + c.sourceOf(target) + " of type " + elemType.String() +
+			" before truncating it is unnecessary, since its elements hold no references",
+		SuggestedFixes: []analysis.SuggestedFix{
+			classify(c.safetyOf(clearStmt.Pos(), target), analysis.SuggestedFix{
+				Message: "Remove the clear.",
+				TextEdits: []analysis.TextEdit{
+					{Pos: clearStmt.Pos(), End: truncation.Pos()},
+				},
+			}),
+		},
+	})
+}
+
+// primitiveElem returns the element type of the slice expr if it neither is nor contains reference types.
+// Type parameters count as primitive only when every type term of their constraint does.
+func (c *checker) primitiveElem(expr ast.Expr) (types.Type, bool) {
+	t := c.pass.TypesInfo.TypeOf(expr)
+	if t == nil {
+		return nil, false
+	}
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok || isOrContainsReferenceTypes(slice.Elem(), true) {
+		return nil, false
+	}
+	return slice.Elem(), true
+}
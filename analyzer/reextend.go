@@ -0,0 +1,117 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// truncationSite is a truncation reported by the main check, recorded for checkReextension.
+type truncationSite struct {
+	stmt   *ast.AssignStmt
+	target ast.Expr
+}
+
+// checkReextension reports reslices that lengthen a slice again after the main check reported its truncation
+// earlier in the same function, as in `s = s[:0]; ...; s = s[:cap(s)]` or `s[:n]`. The stale elements the
+// truncation left behind become visible again. Any write to the slice in between (an append, another reslice,
+// or a clear) is taken to make the reslice safe, and so are truncations that cleared their elements,
+// which the main check does not report in the first place. Only reslices later in the source are considered.
+func (c *checker) checkReextension(inspect *inspector.Inspector) {
+	if len(c.truncated) == 0 {
+		return
+	}
+	info := c.pass.TypesInfo
+	for cur := range inspect.Root().Preorder((*ast.SliceExpr)(nil)) {
+		sliceExpr := cur.Node().(*ast.SliceExpr)
+		if sliceExpr.High == nil || (sliceExpr.Low != nil && !isZeroConst(info, sliceExpr.Low)) || c.isZeroLength(sliceExpr) {
+			continue
+		}
+		target := ast.Unparen(sliceExpr.X)
+		if isLenOf(info, sliceExpr.High, target) {
+			continue
+		}
+		// clear(s[:cap(s)]) is the fix for the truncation, not a use of the stale elements.
+		if call, ok := cur.Parent().Node().(*ast.CallExpr); ok && isBuiltinCall(info, call, "clear") {
+			continue
+		}
+		var body *ast.BlockStmt
+		for fn := range cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+			switch fn := fn.Node().(type) {
+			case *ast.FuncDecl:
+				body = fn.Body
+			case *ast.FuncLit:
+				body = fn.Body
+			}
+			break
+		}
+		if body == nil {
+			continue
+		}
+
+		var site *truncationSite
+		for k := range c.truncated {
+			t := &c.truncated[k]
+			if t.stmt.Pos() >= body.Pos() && t.stmt.End() <= sliceExpr.Pos() && c.sameSlice(t.target, target) {
+				site = t // The last such truncation.
+			}
+		}
+		if site == nil || c.writtenBetween(body, target, site.stmt.End(), sliceExpr.Pos()) {
+			continue
+		}
+		elemType, ok := c.referenceElem(target)
+		if !ok {
+			continue
+		}
+
+		name := c.sourceOf(target)
+		line := c.pass.Fset.Position(site.stmt.Pos()).Line
+		c.pass.Report(analysis.Diagnostic{
+			Pos:      sliceExpr.Pos(),
+			End:      sliceExpr.End(),
+			Category: categoryReextension,
+			Message: "slice " + name + " of type " + elemType.String() + " is re-extended to length " + c.sourceOf(sliceExpr.High) +
+				" after being truncated without clearing elements at line " + strconv.Itoa(line) + "; the stale elements become visible again",
+			Related: []analysis.RelatedInformation{
+				{Pos: site.stmt.Pos(), End: site.stmt.End(), Message: "truncated without clearing elements here"},
+			},
+		})
+	}
+}
+
+// writtenBetween reports whether an assignment to target, a clear of it, or taking its address in body
+// lies entirely within the source range (from, to).
+func (c *checker) writtenBetween(body *ast.BlockStmt, target ast.Expr, from, to token.Pos) bool {
+	info := c.pass.TypesInfo
+	written := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if written || n == nil {
+			return false
+		}
+		if n.Pos() <= from || n.End() > to {
+			// Only descend into nodes overlapping the range.
+			return n.End() > from && n.Pos() < to
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				written = written || c.sameSlice(target, lhs)
+			}
+		case *ast.UnaryExpr:
+			written = n.Op == token.AND && c.sameSlice(target, n.X)
+		case *ast.CallExpr:
+			if isBuiltinCall(info, n, "clear") && len(n.Args) == 1 {
+				arg := ast.Unparen(n.Args[0])
+				if sliceExpr, ok := arg.(*ast.SliceExpr); ok {
+					arg = sliceExpr.X
+				}
+				written = c.sameSlice(target, arg)
+			}
+		}
+		return !written
+	})
+	return written
+}
@@ -0,0 +1,224 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// largeMakeLen is the constant length or capacity from which a slice made with make is considered large
+// by the subslice retention check.
+const largeMakeLen = 4096
+
+// checkSubsliceRetention reports subslices of large local slices that are stored in long-lived places:
+// struct fields (by assignment or in a struct literal), package variables, and maps. The subslice shares the
+// backing array of the large slice, which stays reachable as a whole for as long as the subslice is.
+// A slice is considered large if it is the result of io.ReadAll or os.ReadFile or was made with a length or capacity
+// of at least largeMakeLen. The elements of the result of bytes.Split and similar functions are subslices of their
+// input, so they are reported too. These are heuristics, so the check is opt-in.
+func (c *checker) checkSubsliceRetention(inspect *inspector.Inspector) {
+	info := c.pass.TypesInfo
+
+	// Local variables holding large slices, or the split parts of a slice, mapped to where they came from.
+	large := make(map[*types.Var]string)
+	parts := make(map[*types.Var]string)
+	define := func(name *ast.Ident, value ast.Expr, results int) {
+		v, ok := info.Defs[name].(*types.Var)
+		if !ok || v.Parent() == c.pass.Pkg.Scope() {
+			return
+		}
+		call, ok := ast.Unparen(value).(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		if isBuiltinCall(info, call, "make") {
+			for _, size := range call.Args[1:] {
+				if n, ok := constInt(info, size); ok && n >= largeMakeLen {
+					large[v] = "make"
+				}
+			}
+			return
+		}
+		fn := calledFunc(info, call)
+		if fn == nil || fn.Pkg() == nil {
+			return
+		}
+		switch fn.Pkg().Path() + "." + fn.Name() {
+		case "io.ReadAll", "os.ReadFile":
+			if results == 2 {
+				large[v] = fn.Pkg().Name() + "." + fn.Name()
+			}
+		case "bytes.Split", "bytes.SplitN", "bytes.SplitAfter", "bytes.SplitAfterN", "bytes.Fields", "bytes.FieldsFunc":
+			parts[v] = fn.Pkg().Name() + "." + fn.Name()
+		}
+	}
+	inspect.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE || len(n.Rhs) != 1 {
+				return
+			}
+			if ident, ok := n.Lhs[0].(*ast.Ident); ok {
+				define(ident, n.Rhs[0], len(n.Lhs))
+			}
+		case *ast.ValueSpec:
+			if len(n.Values) == 1 && len(n.Names) > 0 {
+				define(n.Names[0], n.Values[0], len(n.Names))
+			}
+		}
+	})
+	if len(large) == 0 && len(parts) == 0 {
+		return
+	}
+
+	// retained returns the large slice variable whose backing array value shares, and where it came from.
+	retained := func(value ast.Expr) (*types.Var, string) {
+		value = ast.Unparen(value)
+		if sliceExpr, ok := value.(*ast.SliceExpr); ok {
+			if ident, ok := ast.Unparen(sliceExpr.X).(*ast.Ident); ok {
+				if v, ok := info.Uses[ident].(*types.Var); ok && large[v] != "" {
+					return v, large[v]
+				}
+			}
+			value = ast.Unparen(sliceExpr.X)
+		}
+		if index, ok := value.(*ast.IndexExpr); ok {
+			if ident, ok := ast.Unparen(index.X).(*ast.Ident); ok {
+				if v, ok := info.Uses[ident].(*types.Var); ok && parts[v] != "" {
+					return v, parts[v]
+				}
+			}
+		}
+		return nil, ""
+	}
+	report := func(value ast.Expr, dst string) {
+		v, origin := retained(value)
+		if v == nil {
+			return
+		}
+		path := "slices"
+		if isByteSlice(info.TypeOf(value)) && c.fileImports(value.Pos(), "bytes") {
+			path = "bytes"
+		}
+		clone := path + ".Clone"
+		diagnostic := analysis.Diagnostic{
+			Pos:      value.Pos(),
+			End:      value.End(),
+			Category: categorySubsliceRetention,
+			Message: "subslice of " + v.Name() + " (from " + origin + ") stored in " + dst + " keeps its entire backing array reachable; " +
+				"store a copy with " + clone,
+		}
+		// bytes.Clone is available from Go 1.20, and slices.Clone from Go 1.21.
+		pkg, imports, ok := c.slicesName(value.Pos())
+		if path == "bytes" {
+			pkg, imports = c.importName(value.Pos(), path)
+			ok = c.goVersionAtLeast(value.Pos(), "go1.20")
+		}
+		if ok {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+				classify(fixVerify, analysis.SuggestedFix{
+					Message: "Store a copy made with " + clone + ".",
+					TextEdits: append([]analysis.TextEdit{
+						{Pos: value.Pos(), End: value.Pos(), NewText: []byte(pkg + ".Clone(")},
+						{Pos: value.End(), End: value.End(), NewText: []byte(")")},
+					}, imports...),
+				}),
+			}
+		}
+		c.pass.Report(diagnostic)
+	}
+	inspect.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.CompositeLit)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return
+			}
+			for j, lhs := range n.Lhs {
+				if c.isLongLived(lhs) {
+					report(n.Rhs[j], c.sourceOf(lhs))
+				}
+			}
+		case *ast.CompositeLit:
+			t := info.TypeOf(n)
+			if t == nil {
+				return
+			}
+			if _, isStruct := t.Underlying().(*types.Struct); !isStruct {
+				return
+			}
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						report(kv.Value, "field "+key.Name+" of a "+types.TypeString(t, types.RelativeTo(c.pass.Pkg))+" literal")
+					}
+				}
+			}
+		}
+	})
+}
+
+// isLongLived reports whether an assignment to lhs stores the value beyond the enclosing function call:
+// lhs is a struct field, a package variable, or a map element.
+func (c *checker) isLongLived(lhs ast.Expr) bool {
+	info := c.pass.TypesInfo
+	switch lhs := ast.Unparen(lhs).(type) {
+	case *ast.SelectorExpr:
+		selection, ok := info.Selections[lhs]
+		if ok {
+			return selection.Kind() == types.FieldVal
+		}
+		// A qualified identifier, pkg.Var.
+		_, isVar := info.Uses[lhs.Sel].(*types.Var)
+		return isVar
+	case *ast.Ident:
+		v, ok := info.Uses[lhs].(*types.Var)
+		return ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+	case *ast.IndexExpr:
+		_, isMap := info.TypeOf(lhs.X).Underlying().(*types.Map)
+		return isMap
+	default:
+		return false
+	}
+}
+
+// calledFunc returns the package-level function or method called by call, or nil.
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[ident].(*types.Func)
+	return fn
+}
+
+// isByteSlice reports whether t is a slice of bytes.
+func isByteSlice(t types.Type) bool {
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	basic, ok := slice.Elem().Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Byte
+}
+
+// fileImports reports whether the file containing pos imports the package path under its own name.
+func (c *checker) fileImports(pos token.Pos, path string) bool {
+	file := c.fileOf(pos)
+	if file == nil {
+		return false
+	}
+	for _, spec := range file.Imports {
+		if spec.Path.Value == `"`+path+`"` && spec.Name == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkRingSlots reports methods of ring buffers that read a slot and advance past it without clearing it:
+//
+//	x := rb.buf[rb.head]
+//	rb.head = (rb.head + 1) % len(rb.buf)
+//
+// where buf is a slice field of reference types and head an integer field of the receiver. The consumed slot keeps
+// its element reachable until the buffer wraps around. To stay conservative, the read and the advance must both be
+// in the method, and any zero assignment to an element of buf in the method is taken to clear the slot.
+// The fix zeroes the slot, rb.buf[rb.head] = nil, right before the advance.
+func (c *checker) checkRingSlots(inspect *inspector.Inspector) {
+	info := c.pass.TypesInfo
+	for cur := range inspect.Root().Preorder((*ast.FuncDecl)(nil)) {
+		fn := cur.Node().(*ast.FuncDecl)
+		if fn.Recv == nil || fn.Body == nil || len(fn.Recv.List) != 1 || len(fn.Recv.List[0].Names) != 1 {
+			continue
+		}
+		recv, ok := info.Defs[fn.Recv.List[0].Names[0]].(*types.Var)
+		if !ok {
+			continue
+		}
+		// receiverField returns the field of the receiver selected by expr, as in rb.buf.
+		receiverField := func(expr ast.Expr) *types.Var {
+			sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+			if !ok {
+				return nil
+			}
+			if root, ok := ast.Unparen(sel.X).(*ast.Ident); !ok || info.Uses[root] != recv {
+				return nil
+			}
+			selection, ok := info.Selections[sel]
+			if !ok || selection.Kind() != types.FieldVal {
+				return nil
+			}
+			return selection.Obj().(*types.Var)
+		}
+		isIntField := func(expr ast.Expr) bool {
+			field := receiverField(expr)
+			if field == nil {
+				return false
+			}
+			basic, ok := field.Type().Underlying().(*types.Basic)
+			return ok && basic.Info()&types.IsInteger != 0
+		}
+
+		// The slots read as values, the fields whose elements are zeroed, and the advances of index fields.
+		var reads []*ast.IndexExpr
+		written := make(map[ast.Expr]bool)
+		zeroed := make(map[*types.Var]bool)
+		type advance struct {
+			stmt  ast.Stmt
+			index ast.Expr
+			cur   inspector.Cursor
+		}
+		var advances []advance
+		for n := range cur.Preorder((*ast.AssignStmt)(nil), (*ast.IncDecStmt)(nil), (*ast.IndexExpr)(nil)) {
+			switch node := n.Node().(type) {
+			case *ast.AssignStmt:
+				for j, lhs := range node.Lhs {
+					written[ast.Unparen(lhs)] = true
+					if slot, ok := ast.Unparen(lhs).(*ast.IndexExpr); ok && j < len(node.Rhs) && isZeroValue(info, node.Rhs[j]) {
+						if field := receiverField(slot.X); field != nil {
+							zeroed[field] = true
+						}
+					}
+					if isIntField(lhs) && len(node.Lhs) == len(node.Rhs) && mentions(info, node.Rhs[j], lhs) {
+						advances = append(advances, advance{node, lhs, n})
+					}
+				}
+			case *ast.IncDecStmt:
+				if node.Tok == token.INC && isIntField(node.X) {
+					advances = append(advances, advance{node, node.X, n})
+				}
+			case *ast.IndexExpr:
+				field := receiverField(node.X)
+				if written[node] || field == nil || !isIntField(node.Index) {
+					continue
+				}
+				if _, isSlice := field.Type().Underlying().(*types.Slice); isSlice {
+					reads = append(reads, node)
+				}
+			}
+		}
+
+		for _, adv := range advances {
+			for _, read := range reads {
+				field := receiverField(read.X)
+				if read.End() > adv.stmt.Pos() || zeroed[field] || !identicalExpr(info, read.Index, adv.index) {
+					continue
+				}
+				elemType, ok := c.referenceElem(read.X)
+				if !ok {
+					continue
+				}
+				slot := c.sourceOf(read)
+				hint := "set " + slot + " to its zero value before advancing"
+				if zero, _, ok := c.zeroLiteral(adv.stmt.Pos(), elemType); ok {
+					hint = "set " + slot + " = " + zero + " before advancing"
+				}
+				diagnostic := analysis.Diagnostic{
+					Pos:      adv.stmt.Pos(),
+					End:      adv.stmt.End(),
+					Category: categoryRingSlot,
+					Message: "method " + fn.Name.Name + " advances " + c.sourceOf(adv.index) + " past the slot " + slot +
+						" it read without clearing it; the element of type " + elemType.String() + " stays reachable until the buffer wraps around; " + hint,
+				}
+				// The zero assignment can only go in front of a statement of a block.
+				if _, inBlock := adv.cur.Parent().Node().(*ast.BlockStmt); inBlock {
+					diagnostic.SuggestedFixes = c.ringSlotFix(adv.stmt, read, elemType)
+				}
+				c.pass.Report(diagnostic)
+				break
+			}
+		}
+	}
+}
+
+// mentions reports whether expr contains an expression identical to target.
+func mentions(info *types.Info, expr, target ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if e, ok := n.(ast.Expr); ok && identicalExpr(info, e, target) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// ringSlotFix returns the fix zeroing the slot read right before advance, or nil if the zero value of elemType
+// cannot be spelled.
+func (c *checker) ringSlotFix(advance ast.Stmt, read *ast.IndexExpr, elemType types.Type) []analysis.SuggestedFix {
+	zero, imports, ok := c.zeroLiteral(advance.Pos(), elemType)
+	if !ok {
+		return nil
+	}
+	return []analysis.SuggestedFix{
+		classify(c.safetyOf(advance.Pos(), read.X), analysis.SuggestedFix{
+			Message: "Zero the consumed slot before advancing.",
+			TextEdits: append([]analysis.TextEdit{
+				{
+					Pos:     advance.Pos(),
+					End:     advance.Pos(),
+					NewText: []byte(c.sourceOf(read) + " = " + zero + "\n" + c.indentAt(advance.Pos())),
+				},
+			}, imports...),
+		}),
+	}
+}
@@ -0,0 +1,97 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// defaultSecretNames matches the names of variables and fields that usually hold secrets.
+const defaultSecretNames = `(?i)key|secret|token|password|nonce`
+
+// namePattern is a flag.Value holding a regular expression that names are matched against.
+type namePattern struct{ *regexp.Regexp }
+
+func (p *namePattern) String() string {
+	if p.Regexp == nil {
+		return ""
+	}
+	return p.Regexp.String()
+}
+
+func (p *namePattern) Set(value string) error {
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return err
+	}
+	p.Regexp = re
+	return nil
+}
+
+// checkSecretTruncation reports the j-th LHS/RHS pair of assignStmt, a zero-length truncation of lhsExpr, if lhsExpr is
+// a []byte or []rune whose variable or field name matches the secret-names pattern, as in `keyBuf = keyBuf[:0]`.
+// The elements hold no references, but the secret stays in memory until the array is overwritten. A clear or a zeroing
+// loop right before the truncation wipes it. The fix inserts clear(keyBuf) before the truncation.
+// prevStmt is the statement executed immediately before assignStmt, or nil if there is none.
+func (c *checker) checkSecretTruncation(assignStmt *ast.AssignStmt, j int, lhsExpr ast.Expr, sliceName string, prevStmt ast.Stmt) {
+	info := c.pass.TypesInfo
+
+	var ident *ast.Ident
+	switch lhs := ast.Unparen(c.resolve(lhsExpr)).(type) {
+	case *ast.Ident:
+		ident = lhs
+	case *ast.SelectorExpr:
+		ident = lhs.Sel
+	case *ast.StarExpr:
+		switch x := ast.Unparen(lhs.X).(type) {
+		case *ast.Ident:
+			ident = x
+		case *ast.SelectorExpr:
+			ident = x.Sel
+		}
+	}
+	if ident == nil || !c.secretNames.MatchString(ident.Name) {
+		return
+	}
+	slice, ok := info.TypeOf(lhsExpr).Underlying().(*types.Slice)
+	if !ok {
+		return
+	}
+	elem, ok := slice.Elem().Underlying().(*types.Basic)
+	if !ok || (elem.Kind() != types.Uint8 && elem.Kind() != types.Int32) {
+		return
+	}
+	if prevStmt != nil && c.clears(prevStmt, lhsExpr) {
+		return
+	}
+
+	startPos, endPos := assignStmt.Pos(), assignStmt.End()
+	if len(assignStmt.Lhs) > 1 {
+		startPos, endPos = assignStmt.Rhs[j].Pos(), assignStmt.Rhs[j].End()
+	}
+	diagnostic := analysis.Diagnostic{
+		Pos:      startPos,
+		End:      endPos,
+		Category: categorySecret,
+		Message: "slice " + sliceName + " of type " + slice.Elem().String() + " may hold secret data and is resized to zero length " +
+			"without wiping it; clear(" + sliceName + ") (or zero it in a loop) before shrinking",
+	}
+	if len(assignStmt.Lhs) == 1 && assignStmt == c.listStmt && assignStmt.Tok == token.ASSIGN {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(c.safetyOf(assignStmt.Pos(), lhsExpr), analysis.SuggestedFix{
+				Message: "Wipe the elements before truncating.",
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     assignStmt.Pos(),
+						End:     assignStmt.Pos(),
+						NewText: []byte("clear(" + sliceName + ")\n" + c.indentAt(assignStmt.Pos())),
+					},
+				},
+			}),
+		}
+	}
+	c.pass.Report(diagnostic)
+}
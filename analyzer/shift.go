@@ -0,0 +1,229 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkShiftRemove recognizes the ordered removal idiom starting at stmts[i]:
+//
+//	copy(s[i:], s[i+k:])
+//	s = s[:len(s)-k]
+//
+// for a constant k. The shifted elements leave the last k slots beyond the new length holding stale duplicates.
+// Zeroing those slots between the copy and the truncation (`s[len(s)-1] = nil` for a single slot, or
+// `clear(s[len(s)-k:])`) makes the idiom safe. The idiom is reported once, at the truncation, and the fix
+// replaces both statements with slices.Delete(s, i, i+k).
+func (c *checker) checkShiftRemove(stmts []ast.Stmt, i int) {
+	info := c.pass.TypesInfo
+
+	// copy(s[i:], s[i+k:])
+	copyStmt, ok := stmts[i].(*ast.ExprStmt)
+	if !ok || i+1 >= len(stmts) {
+		return
+	}
+	call, ok := ast.Unparen(copyStmt.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || call.Ellipsis.IsValid() {
+		return
+	}
+	if fn, ok := ast.Unparen(call.Fun).(*ast.Ident); !ok {
+		return
+	} else if b, ok := info.Uses[fn].(*types.Builtin); !ok || b.Name() != "copy" {
+		return
+	}
+	target, from := shiftDst(info, call.Args[0])
+	if target == nil {
+		return
+	}
+	src, ok := ast.Unparen(call.Args[1]).(*ast.SliceExpr)
+	if !ok || src.Slice3 || src.Low == nil || (src.High != nil && !isLenOf(info, src.High, target)) || !identicalExpr(info, target, src.X) {
+		return
+	}
+	k := shiftAmount(info, from, src.Low)
+	if k == nil {
+		return
+	}
+	next := i + 1
+
+	// An optional zeroing of the vacated slots.
+	if c.isTailClear(stmts[next], target, k) {
+		next++
+		if next < len(stmts) {
+			if truncation, _ := c.matchTailShrink(stmts[next], target, k); truncation != nil {
+				c.handled[truncation] = true
+			}
+		}
+		return
+	}
+
+	// s = s[:len(s)-k]
+	truncation, _ := c.matchTailShrink(stmts[next], target, k)
+	if truncation == nil || c.handled[truncation] {
+		return
+	}
+	c.handled[truncation] = true
+	elemType, ok := c.referenceElem(target)
+	if !ok {
+		return
+	}
+	name := c.render(target)
+	low := "0"
+	if from != nil {
+		low = c.sourceOf(from)
+	}
+	// Before Go 1.21 there is no slices package to call, so the message names it but no fix is offered,
+	// and neither is one on Go 1.21, whose slices.Delete leaves the vacated slots as they are.
+	pkg, imports, hasSlices := c.slicesName(truncation.Pos())
+	if !hasSlices {
+		pkg = "slices"
+	}
+	keepsTail := hasSlices && !c.deleteClears(truncation.Pos())
+	replacement := pkg + ".Delete(" + name + ", " + low + ", " + c.sourceOf(src.Low) + ")"
+
+	diagnostic := analysis.Diagnostic{
+		Pos:      truncation.Pos(),
+		End:      truncation.End(),
+		Category: categoryTruncation,
+		Message: "slice " + name + " of type " + elemType.String() + " has elements shifted out with copy without clearing " +
+			"the vacated slots beyond its new length; use " + replacement,
+	}
+	if c.inReuseMethod() {
+		diagnostic.Category = categoryReusePoint
+		diagnostic.Message += " (this method is a reuse point; retained elements survive until the next fill)"
+	}
+	if keepsTail {
+		diagnostic.Message += deleteKeepsTailNote
+	}
+	if _, ok := selectorName(target); ok && hasSlices && !keepsTail {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(c.safetyOf(truncation.Pos(), target), analysis.SuggestedFix{
+				Message: "Replace the copy and truncation with slices.Delete to clear the vacated slots.",
+				TextEdits: append([]analysis.TextEdit{
+					{
+						Pos:     copyStmt.Pos(),
+						End:     truncation.End(),
+						NewText: []byte(keepStmtComments(c.pass, copyStmt.Pos(), truncation.End(), name+" = "+replacement)),
+					},
+				}, imports...),
+			}),
+		}
+	}
+	c.pass.Report(diagnostic)
+}
+
+// shiftDst returns the slice and the start index of the copy destination s[i:], s[i:len(s)], or s itself.
+// The start index is nil if the destination starts at zero.
+func shiftDst(info *types.Info, dst ast.Expr) (target, from ast.Expr) {
+	dst = ast.Unparen(dst)
+	sliceExpr, ok := dst.(*ast.SliceExpr)
+	if !ok {
+		if _, isSlice := info.TypeOf(dst).Underlying().(*types.Slice); !isSlice {
+			return nil, nil
+		}
+		return dst, nil
+	}
+	target = ast.Unparen(sliceExpr.X)
+	if _, isSlice := info.TypeOf(target).Underlying().(*types.Slice); !isSlice || sliceExpr.Slice3 {
+		return nil, nil
+	}
+	if sliceExpr.High != nil && !isLenOf(info, sliceExpr.High, target) {
+		return nil, nil
+	}
+	if sliceExpr.Low != nil && !isZeroConst(info, sliceExpr.Low) {
+		from = sliceExpr.Low
+	}
+	return target, from
+}
+
+// shiftAmount returns the positive constant k if low is from+k (or just k if from is nil), or nil otherwise.
+func shiftAmount(info *types.Info, from, low ast.Expr) constant.Value {
+	offset := ast.Unparen(low)
+	if from != nil {
+		binary, ok := offset.(*ast.BinaryExpr)
+		if !ok || binary.Op != token.ADD || !identicalExpr(info, from, binary.X) {
+			return nil
+		}
+		offset = binary.Y
+	}
+	tv, ok := info.Types[offset]
+	if !ok || tv.Value == nil {
+		return nil
+	}
+	k := constant.ToInt(tv.Value)
+	if k.Kind() != constant.Int || constant.Sign(k) <= 0 {
+		return nil
+	}
+	return k
+}
+
+// lenMinus returns x and k if expr is len(x)-k for a constant k, or nil otherwise.
+func lenMinus(info *types.Info, expr ast.Expr) (ast.Expr, constant.Value) {
+	binary, ok := ast.Unparen(expr).(*ast.BinaryExpr)
+	if !ok || binary.Op != token.SUB {
+		return nil, nil
+	}
+	tv, ok := info.Types[binary.Y]
+	if !ok || tv.Value == nil {
+		return nil, nil
+	}
+	call, ok := ast.Unparen(binary.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isLenOf(info, call, call.Args[0]) {
+		return nil, nil
+	}
+	return call.Args[0], constant.ToInt(tv.Value)
+}
+
+// isTailOffset reports whether expr is len(target)-k.
+func isTailOffset(info *types.Info, expr, target ast.Expr, k constant.Value) bool {
+	x, value := lenMinus(info, expr)
+	return x != nil && value.Kind() == constant.Int && constant.Compare(value, token.EQL, k) && identicalExpr(info, x, target)
+}
+
+// matchTailShrink matches the truncation `s = s[:len(s)-k]` of target in stmt.
+func (c *checker) matchTailShrink(stmt ast.Stmt, target ast.Expr, k constant.Value) (*ast.AssignStmt, *ast.SliceExpr) {
+	info := c.pass.TypesInfo
+	truncation, ok := stmt.(*ast.AssignStmt)
+	if !ok || truncation.Tok != token.ASSIGN || len(truncation.Lhs) != 1 || len(truncation.Rhs) != 1 {
+		return nil, nil
+	}
+	sliceExpr, ok := ast.Unparen(truncation.Rhs[0]).(*ast.SliceExpr)
+	if !ok || sliceExpr.Slice3 || !identicalExpr(info, target, truncation.Lhs[0]) || !identicalExpr(info, target, sliceExpr.X) {
+		return nil, nil
+	}
+	if (sliceExpr.Low != nil && !isZeroConst(info, sliceExpr.Low)) || sliceExpr.High == nil || !isTailOffset(info, sliceExpr.High, target, k) {
+		return nil, nil
+	}
+	return truncation, sliceExpr
+}
+
+// isTailClear reports whether stmt zeroes the last k slots of target, with clear(s[len(s)-k:]),
+// or for a single slot also with `s[len(s)-1] = nil`.
+func (c *checker) isTailClear(stmt ast.Stmt, target ast.Expr, k constant.Value) bool {
+	info := c.pass.TypesInfo
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		call, ok := ast.Unparen(stmt.X).(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return false
+		}
+		if fn, ok := ast.Unparen(call.Fun).(*ast.Ident); !ok {
+			return false
+		} else if b, ok := info.Uses[fn].(*types.Builtin); !ok || b.Name() != "clear" {
+			return false
+		}
+		tail, ok := ast.Unparen(call.Args[0]).(*ast.SliceExpr)
+		return ok && tail.Low != nil && identicalExpr(info, target, tail.X) && isTailOffset(info, tail.Low, target, k)
+	case *ast.AssignStmt:
+		if !constant.Compare(k, token.EQL, constant.MakeInt64(1)) {
+			return false
+		}
+		assigned, zeroed := c.matchSlotWrite(stmt, target, &lastIndex{info: info})
+		return assigned && zeroed
+	default:
+		return false
+	}
+}
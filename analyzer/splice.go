@@ -0,0 +1,97 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkAppendSplice reports the j-th LHS/RHS pair of assignStmt if it removes elements of a slice of reference types
+// by splicing it with append, `s = append(s[:i], s[j:]...)`. The elements after the removed range move down,
+// and the last j-i slots beyond the new length keep referencing the elements they held, which are now duplicated
+// within the slice. slices.Delete(s, i, j) performs the same removal and clears those slots.
+func (c *checker) checkAppendSplice(assignStmt *ast.AssignStmt, j int) {
+	info := c.pass.TypesInfo
+
+	target := ast.Unparen(assignStmt.Lhs[j])
+	_, ok := selectorName(target)
+	if star, isStar := target.(*ast.StarExpr); isStar {
+		_, ok = selectorName(star.X)
+	}
+	if !ok {
+		return
+	}
+	name := c.render(target)
+
+	call, ok := ast.Unparen(assignStmt.Rhs[j]).(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || !call.Ellipsis.IsValid() {
+		return
+	}
+	if fn, ok := ast.Unparen(call.Fun).(*ast.Ident); !ok {
+		return
+	} else if b, ok := info.Uses[fn].(*types.Builtin); !ok || b.Name() != "append" {
+		return
+	}
+
+	// s[:i]
+	head, ok := ast.Unparen(call.Args[0]).(*ast.SliceExpr)
+	if !ok || head.Slice3 || head.High == nil || (head.Low != nil && !isZeroConst(info, head.Low)) {
+		return
+	}
+	// s[j:]
+	tail, ok := ast.Unparen(call.Args[1]).(*ast.SliceExpr)
+	if !ok || tail.Slice3 || tail.Low == nil || (tail.High != nil && !isLenOf(info, tail.High, target)) {
+		return
+	}
+	if !identicalExpr(info, target, head.X) || !identicalExpr(info, target, tail.X) {
+		return
+	}
+
+	elemType, ok := c.referenceElem(target)
+	if !ok {
+		return
+	}
+
+	// Before Go 1.21 there is no slices package to call, so the message names it but no fix is offered,
+	// and neither is one on Go 1.21, whose slices.Delete leaves the vacated slots as they are.
+	pkg, imports, hasSlices := c.slicesName(assignStmt.Pos())
+	if !hasSlices {
+		pkg = "slices"
+	}
+	keepsTail := hasSlices && !c.deleteClears(assignStmt.Pos())
+	replacement := pkg + ".Delete(" + name + ", " + c.sourceOf(head.High) + ", " + c.sourceOf(tail.Low) + ")"
+	startPos, endPos := assignStmt.Pos(), assignStmt.End()
+	if len(assignStmt.Lhs) > 1 {
+		startPos, endPos = assignStmt.Rhs[j].Pos(), assignStmt.Rhs[j].End()
+	}
+	diagnostic := analysis.Diagnostic{
+		Pos:      startPos,
+		End:      endPos,
+		Category: categoryTruncation,
+		Message: "slice " + name + " of type " + elemType.String() + " has elements removed with append without clearing " +
+			"the vacated slots beyond its new length; use " + replacement,
+	}
+	if c.inReuseMethod() {
+		diagnostic.Category = categoryReusePoint
+		diagnostic.Message += " (this method is a reuse point; retained elements survive until the next fill)"
+	}
+	if keepsTail {
+		diagnostic.Message += deleteKeepsTailNote
+	}
+	if hasSlices && !keepsTail {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+			classify(c.safetyOf(assignStmt.Pos(), target), analysis.SuggestedFix{
+				Message: "Replace with slices.Delete to clear the vacated slots.",
+				TextEdits: append([]analysis.TextEdit{
+					{
+						Pos:     assignStmt.Rhs[j].Pos(),
+						End:     assignStmt.Rhs[j].End(),
+						NewText: []byte(replacement),
+					},
+				}, imports...),
+			}),
+		}
+	}
+	c.pass.Report(diagnostic)
+}
@@ -0,0 +1,249 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkSwapRemove recognizes the unordered removal idiom starting at stmts[i]:
+//
+//	s[i] = s[len(s)-1]
+//	s = s[:len(s)-1]
+//
+// optionally preceded by `last := len(s)-1` and using last in place of len(s)-1. The vacated slot beyond the new
+// length still references the moved element, keeping it alive after it is later removed from s[i] as well.
+// Zeroing the slot (`s[len(s)-1] = nil`) between the move and the truncation makes the idiom safe.
+// The idiom is reported once, at the truncation, which is then left alone by the other checks.
+func (c *checker) checkSwapRemove(stmts []ast.Stmt, i int) {
+	info := c.pass.TypesInfo
+
+	// An optional last := len(s)-1.
+	last, next := c.matchLastTemp(stmts[i])
+	next += i
+
+	// s[i] = s[len(s)-1]
+	if next+1 >= len(stmts) {
+		return
+	}
+	move, ok := stmts[next].(*ast.AssignStmt)
+	if !ok || move.Tok != token.ASSIGN || len(move.Lhs) != 1 || len(move.Rhs) != 1 {
+		return
+	}
+	dst, ok := ast.Unparen(move.Lhs[0]).(*ast.IndexExpr)
+	if !ok {
+		return
+	}
+	src, ok := ast.Unparen(move.Rhs[0]).(*ast.IndexExpr)
+	if !ok || !identicalExpr(info, dst.X, src.X) || !last.is(src.Index, src.X) {
+		return
+	}
+	target := ast.Unparen(dst.X)
+	if _, isSlice := info.TypeOf(target).Underlying().(*types.Slice); !isSlice {
+		return
+	}
+	next++
+
+	// An optional s[len(s)-1] = nil.
+	assigned, zeroed := c.matchSlotWrite(stmts[next], target, last)
+	if assigned && !zeroed {
+		return // The slot is overwritten with something else, which is not this idiom.
+	}
+	if assigned {
+		next++
+	}
+
+	// s = s[:len(s)-1]
+	if next >= len(stmts) {
+		return
+	}
+	truncation, sliceExpr := c.matchShrink(stmts[next], target, last)
+	if truncation == nil {
+		return
+	}
+
+	// From here on the truncation belongs to the idiom, whether or not it is reported.
+	c.handled[truncation] = true
+	if zeroed {
+		return
+	}
+	elemType, ok := c.referenceElem(target)
+	if !ok {
+		return
+	}
+	name, ok := selectorName(target)
+	if !ok {
+		name = c.sourceOf(target)
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:      truncation.Pos(),
+		End:      truncation.End(),
+		Category: categoryTruncation,
+		Message: "slice " + name + " of type " + elemType.String() + " drops its last element after moving it to " +
+			c.sourceOf(dst) + " without clearing the vacated slot " + name + "[" + c.sourceOf(sliceExpr.High) + "]",
+	}
+	if c.inReuseMethod() {
+		diagnostic.Category = categoryReusePoint
+		diagnostic.Message += " (this method is a reuse point; retained elements survive until the next fill)"
+	}
+	diagnostic.SuggestedFixes = c.zeroSlotFix(truncation, target, sliceExpr.High, elemType)
+	c.pass.Report(diagnostic)
+}
+
+// lastIndex matches the index of the last element of a slice, len(s)-1, or a temp holding it.
+type lastIndex struct {
+	info *types.Info
+	// temp is the variable defined by `last := len(s)-1`, if any, and tempOf is the s it was computed from.
+	// The temp only stands for the last index of that slice.
+	temp   *types.Var
+	tempOf ast.Expr
+}
+
+// matchLastTemp matches an optional `last := len(s)-1` in stmt. It returns the matcher for the last index and
+// the number of statements it consumed, 1 if stmt is such a temp and 0 otherwise.
+func (c *checker) matchLastTemp(stmt ast.Stmt) (*lastIndex, int) {
+	last := &lastIndex{info: c.pass.TypesInfo}
+	define, ok := stmt.(*ast.AssignStmt)
+	if !ok || define.Tok != token.DEFINE || len(define.Lhs) != 1 || len(define.Rhs) != 1 {
+		return last, 0
+	}
+	ident, ok := define.Lhs[0].(*ast.Ident)
+	if !ok {
+		return last, 0
+	}
+	temp, ok := last.info.Defs[ident].(*types.Var)
+	if !ok {
+		return last, 0
+	}
+	tempOf := lenMinusOneOf(last.info, define.Rhs[0])
+	if tempOf == nil {
+		return last, 0
+	}
+	last.temp, last.tempOf = temp, tempOf
+	return last, 1
+}
+
+// is reports whether expr is the last index of target.
+func (l *lastIndex) is(expr, target ast.Expr) bool {
+	if ident, ok := ast.Unparen(expr).(*ast.Ident); ok && l.temp != nil && l.info.Uses[ident] == l.temp {
+		return identicalExpr(l.info, l.tempOf, target)
+	}
+	arg := lenMinusOneOf(l.info, expr)
+	return arg != nil && identicalExpr(l.info, arg, target)
+}
+
+// matchSlotWrite reports whether stmt assigns to the last slot of target, `s[len(s)-1] = v`,
+// and if so, whether v is the zero value.
+func (c *checker) matchSlotWrite(stmt ast.Stmt, target ast.Expr, last *lastIndex) (assigned, zeroed bool) {
+	info := c.pass.TypesInfo
+	write, ok := stmt.(*ast.AssignStmt)
+	if !ok || write.Tok != token.ASSIGN || len(write.Lhs) != 1 || len(write.Rhs) != 1 {
+		return false, false
+	}
+	slot, ok := ast.Unparen(write.Lhs[0]).(*ast.IndexExpr)
+	if !ok || !identicalExpr(info, target, slot.X) || !last.is(slot.Index, target) {
+		return false, false
+	}
+	return true, isZeroValue(info, write.Rhs[0])
+}
+
+// matchShrink matches the truncation `s = s[:len(s)-1]` of target in stmt.
+func (c *checker) matchShrink(stmt ast.Stmt, target ast.Expr, last *lastIndex) (*ast.AssignStmt, *ast.SliceExpr) {
+	info := c.pass.TypesInfo
+	truncation, ok := stmt.(*ast.AssignStmt)
+	if !ok || truncation.Tok != token.ASSIGN || len(truncation.Lhs) != 1 || len(truncation.Rhs) != 1 {
+		return nil, nil
+	}
+	sliceExpr, ok := ast.Unparen(truncation.Rhs[0]).(*ast.SliceExpr)
+	if !ok || !c.isShrinkOf(sliceExpr, target, last) || !identicalExpr(info, target, truncation.Lhs[0]) {
+		return nil, nil
+	}
+	return truncation, sliceExpr
+}
+
+// isShrinkOf reports whether sliceExpr is target[:len(target)-1].
+func (c *checker) isShrinkOf(sliceExpr *ast.SliceExpr, target ast.Expr, last *lastIndex) bool {
+	info := c.pass.TypesInfo
+	if sliceExpr.Slice3 || !identicalExpr(info, target, sliceExpr.X) {
+		return false
+	}
+	if sliceExpr.Low != nil && !isZeroConst(info, sliceExpr.Low) {
+		return false
+	}
+	return sliceExpr.High != nil && last.is(sliceExpr.High, target)
+}
+
+// zeroSlotFix returns the fix zeroing the slot target[index] right before the truncation,
+// or nil if the slice cannot be named again or its zero value cannot be spelled.
+func (c *checker) zeroSlotFix(truncation ast.Stmt, target, index ast.Expr, elemType types.Type) []analysis.SuggestedFix {
+	name, ok := selectorName(target)
+	if !ok {
+		return nil
+	}
+	zero, imports, ok := c.zeroLiteral(truncation.Pos(), elemType)
+	if !ok {
+		return nil
+	}
+	return []analysis.SuggestedFix{
+		classify(c.safetyOf(truncation.Pos(), target), analysis.SuggestedFix{
+			Message: "Zero the vacated slot before truncating.",
+			TextEdits: append([]analysis.TextEdit{
+				{
+					Pos:     truncation.Pos(),
+					End:     truncation.Pos(),
+					NewText: []byte(name + "[" + c.sourceOf(index) + "] = " + zero + "\n" + c.indentAt(truncation.Pos())),
+				},
+			}, imports...),
+		}),
+	}
+}
+
+// lenMinusOneOf returns x if expr is len(x)-1, or nil otherwise.
+func lenMinusOneOf(info *types.Info, expr ast.Expr) ast.Expr {
+	x, k := lenMinus(info, expr)
+	if x == nil || !constant.Compare(k, token.EQL, constant.MakeInt64(1)) {
+		return nil
+	}
+	return x
+}
+
+// isZeroValue reports whether expr is spelled as a zero value: nil, *new(T), or an empty composite literal T{}.
+func isZeroValue(info *types.Info, expr ast.Expr) bool {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		_, isNil := info.Uses[expr].(*types.Nil)
+		return isNil
+	case *ast.CompositeLit:
+		return len(expr.Elts) == 0
+	case *ast.StarExpr:
+		call, ok := ast.Unparen(expr.X).(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return false
+		}
+		fn, ok := ast.Unparen(call.Fun).(*ast.Ident)
+		if !ok {
+			return false
+		}
+		b, ok := info.Uses[fn].(*types.Builtin)
+		return ok && b.Name() == "new"
+	default:
+		return false
+	}
+}
+
+// isNillable reports whether nil is a value of t.
+func isNillable(t types.Type) bool {
+	if _, isTypeParam := t.(*types.TypeParam); isTypeParam {
+		return false
+	}
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Interface, *types.Slice, *types.Map, *types.Chan, *types.Signature:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,58 @@
+// Package old is compiled for Go 1.20, which has neither the clear built-in nor the slices package.
+package old
+
+import (
+	"strings"
+	"sync"
+)
+
+type Conn struct{}
+
+type pool struct {
+	conns []*Conn
+}
+
+func truncate(ids []*int) []*int {
+	ids = ids[:0] // want `slice ids of type \*int is resized to zero length without clearing elements$`
+	return ids
+}
+
+func (p *pool) reset() {
+	p.conns = p.conns[:0] // want `slice p.conns of type \*example.com/go120/old.Conn is resized to zero length without clearing elements$`
+}
+
+func (p *pool) resetThrough(q *[]*Conn) {
+	*q = (*q)[:0] // want `slice \*q of type \*example.com/go120/old.Conn is resized to zero length without clearing elements$`
+}
+
+func byIndex(m map[int][]*Conn, i int) {
+	// The loop index must not hide the key
+	m[i] = m[i][:0] // want `slice m\[i\] of type \*example.com/go120/old.Conn is resized to zero length without clearing elements$`
+}
+
+func pair(a, b []*Conn) ([]*Conn, []*Conn) {
+	a, b = a[:0], b // want `slice a of type \*example.com/go120/old.Conn is resized to zero length without clearing elements \(no fix is suggested: Go 1.20 has neither clear nor slices.Delete, and the elements cannot be zeroed in a loop here\)`
+	return a, b
+}
+
+func builders(bs []strings.Builder) []strings.Builder {
+	bs = bs[:0] // want `slice bs of type strings.Builder is resized to zero length without clearing elements$`
+	return bs
+}
+
+func shadowed(bs []strings.Builder, strings int) []strings.Builder {
+	// The parameter hides the package, so the zero value refers to it through a second import
+	bs = bs[:0] // want `slice bs of type strings.Builder is resized to zero length without clearing elements$`
+	return bs
+}
+
+func (p *pool) remove(i int) {
+	copy(p.conns[i:], p.conns[i+1:])
+	p.conns = p.conns[:len(p.conns)-1] // want `slice p.conns of type \*example.com/go120/old.Conn has elements shifted out with copy without clearing the vacated slots beyond its new length; use slices.Delete\(p.conns, i, i\+1\)`
+}
+
+var connPool sync.Pool
+
+func release(conns []*Conn) {
+	connPool.Put(conns) // want `slice conns of type \*example.com/go120/old.Conn is put into a sync.Pool without clearing elements`
+}
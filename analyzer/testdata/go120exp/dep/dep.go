@@ -0,0 +1,10 @@
+// Package dep only reaches golang.org/x/exp/slices through the package old it imports, which does not guarantee
+// that its module requires x/exp, so its fixes do not use it.
+package dep
+
+import "example.com/go120exp/old"
+
+func filter(conns []*old.Conn) ([]*old.Conn, int) {
+	kept := append(conns[:0], conns[1:]...) // want `kept is built with append\(conns\[:0\], ...\)`
+	return kept, len(conns)
+}
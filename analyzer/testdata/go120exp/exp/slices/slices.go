@@ -0,0 +1,11 @@
+// Package slices is a stub of golang.org/x/exp/slices for the tests. Like the real package before Go 1.21, its
+// Delete leaves the removed elements in place.
+package slices
+
+func Clone[S ~[]E, E any](s S) S {
+	return append(S(nil), s...)
+}
+
+func Delete[S ~[]E, E any](s S, i, j int) S {
+	return append(s[:i], s[j:]...)
+}
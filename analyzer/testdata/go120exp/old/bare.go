@@ -0,0 +1,6 @@
+package old
+
+func filterBare(conns []*Conn) ([]*Conn, int) {
+	kept := append(conns[:0], conns[1:]...) // want `kept is built with append\(conns\[:0\], ...\)`
+	return kept, len(conns)
+}
@@ -0,0 +1,35 @@
+// Package old is compiled for Go 1.20, which predates the slices package of the standard library,
+// but already uses golang.org/x/exp/slices, so fixes call that one instead.
+package old
+
+import (
+	"strings"
+
+	xslices "golang.org/x/exp/slices"
+)
+
+type Conn struct{}
+
+type pool struct {
+	conns []*Conn
+}
+
+func names(b *strings.Builder, conns []*Conn) []*Conn {
+	b.WriteString("conns")
+	return xslices.Clone(conns)
+}
+
+func filter(conns []*Conn) ([]*Conn, int) {
+	kept := append(conns[:0], conns[1:]...) // want `kept is built with append\(conns\[:0\], ...\)`
+	return kept, len(conns)
+}
+
+func (p *pool) reset() {
+	// Before Go 1.22, the slices.Delete of x/exp does not clear either, so the elements are zeroed in a loop.
+	p.conns = p.conns[:0] // want `slice p.conns of type \*example.com/go120exp/old.Conn is resized to zero length without clearing elements$`
+}
+
+func (p *pool) remove(i int) {
+	copy(p.conns[i:], p.conns[i+1:])
+	p.conns = p.conns[:len(p.conns)-1] // want `use xslices.Delete\(p.conns, i, i\+1\) \(slices.Delete clears the vacated slots only from Go 1.22; clear them explicitly before that\)`
+}
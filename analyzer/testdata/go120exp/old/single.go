@@ -0,0 +1,9 @@
+package old
+
+import "strings"
+
+func filterNamed(b *strings.Builder, conns []*Conn) ([]*Conn, int) {
+	b.WriteString("filter")
+	kept := append(conns[:0], conns[1:]...) // want `kept is built with append\(conns\[:0\], ...\)`
+	return kept, len(conns)
+}
@@ -0,0 +1,35 @@
+// Package old is compiled for Go 1.21, whose slices.Delete does not yet clear the elements it removes.
+package old
+
+import "slices"
+
+type Conn struct{}
+
+type pool struct {
+	conns []*Conn
+}
+
+func (p *pool) reset() {
+	p.conns = p.conns[:0] // want `slice p.conns of type \*example.com/go121/old.Conn is resized to zero length without clearing elements$`
+}
+
+func pair(a, b []*Conn) ([]*Conn, []*Conn) {
+	a, b = a[:0], b // want `slice a of type \*example.com/go121/old.Conn is resized to zero length without clearing elements \(no fix is suggested: slices.Delete only clears the removed elements from Go 1.22, and clear needs a statement of its own here\)`
+	return a, b
+}
+
+func deleted(conns []*Conn) []*Conn {
+	// The deletion leaves the elements in place, so it does not count as a clear.
+	conns = slices.Delete(conns, 0, len(conns))
+	conns = conns[:0] // want `slice conns of type \*example.com/go121/old.Conn is resized to zero length without clearing elements$`
+	return conns
+}
+
+func (p *pool) remove(i int) {
+	copy(p.conns[i:], p.conns[i+1:])
+	p.conns = p.conns[:len(p.conns)-1] // want `slice p.conns of type \*example.com/go121/old.Conn has elements shifted out with copy without clearing the vacated slots beyond its new length; use slices.Delete\(p.conns, i, i\+1\) \(slices.Delete clears the vacated slots only from Go 1.22; clear them explicitly before that\)`
+}
+
+func (p *pool) splice(i, j int) {
+	p.conns = append(p.conns[:i], p.conns[j:]...) // want `slice p.conns of type \*example.com/go121/old.Conn has elements removed with append without clearing the vacated slots beyond its new length; use slices.Delete\(p.conns, i, j\) \(slices.Delete clears the vacated slots only from Go 1.22`
+}
@@ -0,0 +1,3 @@
+module example.com/mapclear120
+
+go 1.20
@@ -0,0 +1,8 @@
+// Package old is compiled for Go 1.20, which has no clear built-in.
+package old
+
+func reset(m map[string]*int) {
+	for k := range m {
+		delete(m, k)
+	}
+}
@@ -0,0 +1,3 @@
+module example.com/modernize120
+
+go 1.20
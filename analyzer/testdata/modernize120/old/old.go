@@ -0,0 +1,14 @@
+// Package old is compiled for Go 1.20, which has no clear built-in.
+package old
+
+func zero(ids []*int) {
+	for i := range ids {
+		ids[i] = nil
+	}
+}
+
+var zeroPtrs = make([]*int, 16)
+
+func zeroCopy(ids []*int) {
+	copy(ids, zeroPtrs)
+}
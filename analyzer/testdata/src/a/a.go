@@ -172,9 +172,134 @@ func _() {
 	runtime.KeepAlive(s)
 }
 
+func _() {
+	// Safe: clear() of the full length, spelled out
+	s := []*int{new(int), new(int)}
+	clear(s[:len(s)])
+	s = s[:0]
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Safe: clear() of the full capacity
+	s := []*int{new(int), new(int)}
+	clear(s[0:cap(s)])
+	s = s[:0]
+	runtime.KeepAlive(s)
+}
+
+func _(c *Client) {
+	// Safe: clear() of the full capacity of the same selector chain
+	clear(c.group.state.bufs[:cap(c.group.state.bufs)])
+	c.group.state.bufs = c.group.state.bufs[:0]
+}
+
+func _(s, t []*int) {
+	// Unsafe: the bounds belong to a different slice
+	clear(s[:len(t)])
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
 func _() {
 	// Recommended pattern: use slices.Delete(s, 0, len(s)) to clear elements up to length
 	s := []*int{new(int), new(int)}
 	s = slices.Delete(s, 0, len(s))
 	runtime.KeepAlive(s)
 }
+
+type Conn struct {
+	addr string
+}
+
+func connKey() string { return "k" }
+
+func _() {
+	// Unsafe: map value truncated in place through an identifier key
+	cache := map[string][]*Conn{}
+	key := "k"
+	cache[key] = cache[key][:0] // want `slice cache\[key\] of type \*a.Conn is resized to zero length without clearing elements`
+}
+
+func _() {
+	// Unsafe: map value truncated in place through a literal key
+	cache := map[string][]*Conn{}
+	cache["k"] = cache["k"][:0] // want `slice cache\["k"\] of type \*a.Conn is resized to zero length without clearing elements`
+}
+
+func _() {
+	// Unsafe: map value truncated through a key with side effects (reported without a fix)
+	cache := map[string][]*Conn{}
+	cache[connKey()] = cache[connKey()][:0] // want `slice cache\[connKey\(\)\] of type \*a.Conn is resized to zero length without clearing elements`
+}
+
+func _() {
+	// Safe: map value elements do not contain references
+	counts := map[string][]int{}
+	counts["k"] = counts["k"][:0]
+}
+
+func _() {
+	// Safe: different map keys on each side
+	cache := map[string][]*Conn{}
+	cache["a"] = cache["b"][:0]
+}
+
+type Item struct {
+	payload *[]byte
+}
+
+func _(p *[]*Item) {
+	// Unsafe: slice truncated through a pointer dereference
+	*p = (*p)[:0] // want `slice \*p of type \*a.Item is resized to zero length without clearing elements`
+}
+
+func _(p *[]*Item) {
+	// Safe: clear() through the same dereference directly preceding length adjustment
+	clear(*p)
+	*p = (*p)[:0]
+}
+
+func _(p *[]int) {
+	// Safe: dereferenced slice of primitive types
+	*p = (*p)[:0]
+}
+
+func _(p, q *[]*Item) {
+	// Safe: different pointers on each side
+	*p = (*q)[:0]
+}
+
+type connState struct {
+	bufs []*Conn
+}
+
+type connGroup struct {
+	state connState
+}
+
+type Client struct {
+	state connState
+	group *connGroup
+}
+
+func (c *Client) resetState() {
+	// Unsafe: two-level selector chain rooted at a pointer receiver
+	c.state.bufs = c.state.bufs[:0] // want `slice c.state.bufs of type \*a.Conn is resized to zero length without clearing elements`
+}
+
+func (c *Client) resetGroup() {
+	// Unsafe: three-level selector chain through a pointer field
+	c.group.state.bufs = c.group.state.bufs[:0] // want `slice c.group.state.bufs of type \*a.Conn is resized to zero length without clearing elements`
+}
+
+func (c *Client) resetGroupCleared() {
+	// Safe: clear() of the same selector chain directly preceding length adjustment
+	clear(c.group.state.bufs)
+	c.group.state.bufs = c.group.state.bufs[:0]
+}
+
+func (c *Client) resetMismatched() {
+	// Safe: the chains differ, so this is not a self-truncation
+	c.state.bufs = c.group.state.bufs[:0]
+}
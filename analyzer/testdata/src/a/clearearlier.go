@@ -0,0 +1,257 @@
+package a
+
+import (
+	"log"
+	"slices"
+)
+
+func _(s []*int) {
+	// Safe: clear() earlier in the block, with statements in between that do not touch s
+	clear(s)
+	log.Println("reset", len(s))
+	s = s[:0]
+	_ = s
+}
+
+func _(c *Client) {
+	// Safe: clear() of the same selector chain earlier in the block
+	clear(c.group.state.bufs)
+	n := cap(c.group.state.bufs)
+	log.Println("reset", n)
+	c.group.state.bufs = c.group.state.bufs[:0]
+}
+
+func _(s []*int, p *int) {
+	// Unsafe: the append after the clear refills the slice
+	clear(s)
+	s = append(s, p)
+	log.Println("reset")
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*int, p *int) {
+	// Unsafe: an element is stored after the clear
+	clear(s)
+	s[0] = p
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*int, fill func([]*int)) {
+	// Unsafe: the slice is passed to a function after the clear
+	clear(s)
+	fill(s)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s, t []*int) {
+	// Unsafe: the clear is for a different slice
+	clear(t)
+	log.Println("reset")
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*int) {
+	// Safe: the elements are zeroed by a loop before the truncation
+	for i := range s {
+		s[i] = nil
+	}
+	s = s[:0]
+	_ = s
+}
+
+func _(items []Item) {
+	// Safe: zeroed with an empty composite literal, with a statement in between
+	for i := range items {
+		items[i] = Item{}
+	}
+	log.Println("reset")
+	items = items[:0]
+	_ = items
+}
+
+func _(items []Item) {
+	// Safe: zeroed with a variable that provably holds the zero value
+	var zero Item
+	for i := range items {
+		items[i] = zero
+	}
+	items = items[:0]
+	_ = items
+}
+
+func _(items []Item, last Item) {
+	// Unsafe: the assigned value is not zero
+	for i := range items {
+		items[i] = last
+	}
+	items = items[:0] // want `slice items of type a.Item is resized to zero length without clearing elements`
+	_ = items
+}
+
+func _(s []*int) {
+	// Unsafe: the loop zeroes only some of the elements
+	for i := range s {
+		if i%2 == 0 {
+			s[i] = nil
+		}
+	}
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*int) {
+	// Safe, if redundant: slices.Delete already cleared the elements
+	s = slices.Delete(s, 0, len(s))
+	s = s[:0]
+	_ = s
+}
+
+func _(c *Client) {
+	// Safe: the same for a selector chain, with a statement in between
+	c.group.state.bufs = slices.Delete(c.group.state.bufs, 0, len(c.group.state.bufs))
+	log.Println("reset")
+	c.group.state.bufs = c.group.state.bufs[:0]
+}
+
+func _(s []*int, n int) {
+	// Unsafe: only part of the slice is deleted
+	s = slices.Delete(s, 0, n)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s, t []*int) {
+	// Unsafe: the result of the delete is assigned to a different slice
+	t = slices.Delete(s, 0, len(s))
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_, _ = s, t
+}
+
+func _(s []*int, needReset bool) {
+	// Safe: clear() in an enclosing block before the nested truncation
+	clear(s)
+	if needReset {
+		s = s[:0]
+	}
+	_ = s
+}
+
+func _(s []*int, mode int) {
+	// Safe: clear() several blocks up, through a switch and a bare block
+	clear(s)
+	log.Println("reset")
+	switch mode {
+	case 1:
+		{
+			s = s[:0]
+		}
+	}
+	_ = s
+}
+
+func _(s []*int, a, b bool) {
+	// Safe: clear() before an else-if chain
+	clear(s)
+	if a {
+		log.Println("a")
+	} else if b {
+		s = s[:0]
+	}
+	_ = s
+}
+
+func _(s []*int, p *int, needReset bool) {
+	// Unsafe: the slice is appended to between the clear and the nested truncation
+	clear(s)
+	s = append(s, p)
+	if needReset {
+		s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	}
+	_ = s
+}
+
+func _(s []*int, fill func([]*int) bool) {
+	// Unsafe: the condition passes the slice to a function
+	clear(s)
+	if fill(s) {
+		s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	}
+	_ = s
+}
+
+func _(s []*int, ps []*int) {
+	// Unsafe: the loop refills the slice after the truncation for the next iteration
+	clear(s)
+	for _, p := range ps {
+		s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+		s = append(s, p)
+	}
+	_ = s
+}
+
+func _(s []*int) {
+	// Safe: clear() of a plain copy shares the backing array
+	tmp := s
+	clear(tmp)
+	s = s[:0]
+	_ = s
+}
+
+func _(c *Client) {
+	// Safe: the same for a copy of a field, cleared up to its capacity
+	bufs := c.group.state.bufs
+	log.Println("reset")
+	clear(bufs[:cap(bufs)])
+	c.group.state.bufs = c.group.state.bufs[:0]
+}
+
+func _(s []*int) {
+	// Safe: the other way around, the copy is truncated after clearing the original
+	var tmp []*int
+	tmp = s
+	clear(s)
+	tmp = tmp[:0]
+	_ = tmp
+}
+
+func _(s, other []*int) {
+	// Unsafe: the copy is reassigned before the clear
+	tmp := s
+	tmp = other
+	clear(tmp)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_, _ = s, tmp
+}
+
+func _(s, other []*int) {
+	// Unsafe: the original is reassigned before the clear of the copy
+	tmp := s
+	s = other
+	clear(tmp)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*int, p *int) {
+	// Unsafe: the copy is refilled after the clear
+	tmp := s
+	clear(tmp)
+	tmp[0] = p
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s, other []*int, swap bool) {
+	// Unsafe: the copy is not made on every path to the clear
+	tmp := other
+	if swap {
+		tmp = s
+	}
+	clear(tmp)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
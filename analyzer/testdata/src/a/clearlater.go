@@ -0,0 +1,56 @@
+package a
+
+func _(s []*int) {
+	// Safe: the full capacity is cleared right after the truncation
+	s = s[:0]
+	clear(s[:cap(s)])
+	_ = s
+}
+
+func _(s []*int) {
+	// Safe: statements not touching s may come in between
+	s = s[:0]
+	println("reset")
+	clear(s[:cap(s)])
+	_ = s
+}
+
+func _(s []*int) {
+	// Safe: the length saved before the truncation covers the stale elements
+	n := len(s)
+	s = s[:0]
+	clear(s[:n])
+	_ = s
+}
+
+func _(s []*int, use func([]*int)) {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	use(s)
+	clear(s[:cap(s)])
+}
+
+func _(s []*int, done bool) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	if done {
+		return s
+	}
+	clear(s[:cap(s)])
+	return s
+}
+
+func _(s []*int) {
+	// The length saved may have changed before the truncation.
+	n := len(s)
+	s = append(s, nil)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	clear(s[:n])
+	_ = s
+}
+
+func _(s []*int) {
+	n := len(s)
+	n--
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	clear(s[:n])
+	_ = s
+}
@@ -0,0 +1,53 @@
+package a
+
+import (
+	"limits"
+	"runtime"
+)
+
+const zero = 0
+
+const one = 1
+
+func _() {
+	// Unsafe: high index is a named constant zero
+	s := []*int{new(int)}
+	s = s[:zero] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: high index is a hexadecimal zero literal
+	s := []*int{new(int)}
+	s = s[:0x0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: high index is a converted zero literal
+	s := []*int{new(int)}
+	s = s[:int(0)] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: high index is a constant zero imported from another package
+	s := []*int{new(int)}
+	s = s[:limits.Empty] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: constant expressions folding to zero on both bounds
+	s := []*int{new(int)}
+	s = s[one-1 : zero*2] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Safe: high index is a nonzero constant
+	s := make([]*int, 16)
+	s = s[:one]
+	s = s[:limits.Batch]
+	runtime.KeepAlive(s)
+}
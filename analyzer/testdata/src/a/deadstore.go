@@ -0,0 +1,70 @@
+package a
+
+type deadStoreHolder struct {
+	items []*int
+}
+
+var deadStorePkg []*int
+
+func _() {
+	// Safe: the truncated local is never used again
+	buf := make([]*int, 8)
+	buf = buf[:0]
+}
+
+func _() {
+	buf := make([]*int, 8)
+	buf = buf[:0] // want `slice buf of type \*int is resized to zero length without clearing elements`
+	buf = append(buf, nil)
+	_ = buf
+}
+
+func _() []*int {
+	buf := make([]*int, 8)
+	buf = buf[:0] // want `slice buf of type \*int is resized to zero length without clearing elements`
+	return buf
+}
+
+func _() {
+	buf := make([]*int, 8)
+	p := &buf
+	buf = buf[:0] // want `slice buf of type \*int is resized to zero length without clearing elements`
+	_ = p
+}
+
+func _() func() int {
+	buf := make([]*int, 8)
+	f := func() int { return len(buf) }
+	buf = buf[:0] // want `slice buf of type \*int is resized to zero length without clearing elements`
+	return f
+}
+
+func _(n int) {
+	buf := make([]*int, 8)
+	for range n {
+		// The next iteration reads the truncated value.
+		buf = append(buf, nil)
+		buf = buf[:0] // want `slice buf of type \*int is resized to zero length without clearing elements`
+	}
+}
+
+func _(h *deadStoreHolder) {
+	h.items = h.items[:0] // want `slice h.items of type \*int is resized to zero length without clearing elements`
+}
+
+func _() {
+	deadStorePkg = deadStorePkg[:0] // want `slice deadStorePkg of type \*int is resized to zero length without clearing elements`
+}
+
+func _() {
+	f := func(s []*int) {
+		s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	}
+	_ = f
+}
+
+func _() (out []*int) {
+	out = make([]*int, 8)
+	out = out[:0] // want `slice out of type \*int is resized to zero length without clearing elements`
+	return
+}
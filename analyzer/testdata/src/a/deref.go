@@ -0,0 +1,41 @@
+package a
+
+type Token struct {
+	text *string
+}
+
+type tokenBuffer struct {
+	pending []*Token
+}
+
+type Parser struct {
+	tokenBuffer
+	tokens []*Token
+}
+
+func (p *Parser) resetTokens() {
+	// Unsafe: selector rooted at an explicit dereference
+	(*p).tokens = (*p).tokens[:0] // want `slice \(\*p\).tokens of type \*a.Token is resized to zero length without clearing elements`
+}
+
+func (p *Parser) resetTokensCleared() {
+	// Safe: clear() through the same explicit dereference directly preceding length adjustment
+	clear((*p).tokens)
+	(*p).tokens = (*p).tokens[:0]
+}
+
+func (p *Parser) resetPending() {
+	// Unsafe: promoted field accessed through an explicit dereference
+	(*p).pending = ((*p).pending)[:0] // want `slice \(\*p\).pending of type \*a.Token is resized to zero length without clearing elements`
+}
+
+func (p *Parser) resetPendingCleared() {
+	// Safe: clear() of the promoted field through an explicit dereference
+	clear((*p).pending)
+	(*p).pending = (*p).pending[:0]
+}
+
+func resetParsers(p, q *Parser) {
+	// Safe: different parsers on each side
+	(*p).tokens = (*q).tokens[:0]
+}
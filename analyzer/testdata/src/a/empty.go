@@ -0,0 +1,58 @@
+package a
+
+func _(cond bool) {
+	// Safe: a nil slice has no elements to clear
+	var s []*int
+	if cond {
+		s = s[:0]
+	}
+	println(len(s), cap(s))
+}
+
+func _(n int) {
+	// Safe: made with zero length and never written, even in a loop
+	s := make([]*int, 0, 16)
+	for range n {
+		s = s[:0]
+		for _, p := range s {
+			println(p)
+		}
+	}
+}
+
+func _() {
+	// Safe: an empty literal
+	s := []*int{}
+	s = s[:0]
+	println(len(s))
+}
+
+func _(x *int) {
+	s := make([]*int, 0, 16)
+	s = append(s, x)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	println(len(s))
+}
+
+func _(fill func([]*int)) {
+	// A callee may write beyond the length.
+	s := make([]*int, 0, 16)
+	fill(s)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	println(len(s))
+}
+
+func _(x *int) {
+	// An append to another variable writes into the backing array of s.
+	s := make([]*int, 0, 16)
+	t := append(s, x)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	println(len(s), len(t))
+}
+
+func _(xs []*int) {
+	s := make([]*int, 0, 16)
+	copy(s[:cap(s)], xs)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	println(len(s))
+}
@@ -0,0 +1,82 @@
+package a
+
+import "slices"
+
+type freshHolder struct {
+	items []*int
+}
+
+func _(s []*int, n int) []*int {
+	// Safe: the truncated header is replaced right away
+	s = s[:0]
+	s = make([]*int, 0, n)
+	return s
+}
+
+func _(s []*int, cond bool) []*int {
+	// Safe: the reallocation follows the enclosing if
+	if cond {
+		s = s[:0]
+		println("reset")
+	}
+	s = nil
+	return s
+}
+
+func _(s, other []*int) []*int {
+	// Safe: cloned from another slice, sized by the old one
+	s = s[:0]
+	s = slices.Clone(other)
+	t := make([]*int, 0, cap(s))
+	return append(t, s...)
+}
+
+func _(h *freshHolder, x *int) {
+	// Safe: nothing in between can read the field
+	h.items = h.items[:0]
+	h.items = []*int{x}
+}
+
+func _(s []*int) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	println(len(s))
+	s = make([]*int, 0, 4)
+	return s
+}
+
+func _(s []*int, n int) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	s = make([]*int, 0, n)[:0:len(s)]
+	return s
+}
+
+func _(s []*int, n int) []*int {
+	for range n {
+		// The next iteration observes the truncated header.
+		s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	}
+	s = nil
+	return s
+}
+
+func _(h *freshHolder, x *int, log func()) {
+	// A call may read the field.
+	h.items = h.items[:0] // want `slice h.items of type \*int is resized to zero length without clearing elements`
+	log()
+	h.items = []*int{x}
+}
+
+func _(s []*int, done bool) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	if done {
+		return s
+	}
+	s = nil
+	return s
+}
+
+func _(s []*int, n int) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	s = append(make([]*int, 0, n), s...)
+	return s
+}
@@ -0,0 +1,146 @@
+package a
+
+import "runtime"
+
+func _(ready bool) {
+	// Unsafe: truncation in an if-statement init clause
+	s := []*int{new(int)}
+	if s = s[:0]; ready { // want `slice s of type \*int is resized to zero length without clearing elements`
+		runtime.KeepAlive(s)
+	}
+}
+
+func _(ready bool) {
+	// Safe: clear() directly preceding the if statement
+	s := []*int{new(int)}
+	clear(s)
+	if s = s[:0]; ready {
+		runtime.KeepAlive(s)
+	}
+}
+
+func _(ready, retry bool) {
+	// Unsafe: truncation in an else-if init clause
+	s := []*int{new(int)}
+	if ready {
+		runtime.KeepAlive(s)
+	} else if s = s[:0]; retry { // want `slice s of type \*int is resized to zero length without clearing elements`
+		runtime.KeepAlive(s)
+	}
+}
+
+func _(ready, retry bool) {
+	// Safe: clear() in the init clause of the preceding if in the chain
+	s := []*int{new(int)}
+	if clear(s); ready {
+		runtime.KeepAlive(s)
+	} else if s = s[:0]; retry {
+		runtime.KeepAlive(s)
+	}
+}
+
+func _(ready bool) {
+	// Safe: primitive slice truncated in an if-statement init clause
+	s := []int{1}
+	if s = s[:0]; ready {
+		runtime.KeepAlive(s)
+	}
+}
+
+func _(input []string) {
+	// Unsafe: truncations in both the init and post statements of a for loop
+	q := []*string{new(string)}
+	for q = q[:0]; len(input) > 0; q = q[:0] { // want `slice q of type \*string is resized to zero length without clearing elements` `slice q of type \*string is resized to zero length without clearing elements`
+		q = append(q, &input[0])
+		input = input[1:]
+	}
+	runtime.KeepAlive(q)
+}
+
+func _(input []string) {
+	// Safe: clear() preceding the loop and at the end of the loop body
+	q := []*string{new(string)}
+	clear(q)
+	for q = q[:0]; len(input) > 0; q = q[:0] {
+		q = append(q, &input[0])
+		input = input[1:]
+		clear(q)
+	}
+	runtime.KeepAlive(q)
+}
+
+func _(input []string) {
+	// Unsafe: the clear() at the end of the body is for a different slice
+	q := []*string{new(string)}
+	other := []*string{new(string)}
+	for ; len(input) > 0; q = q[:0] { // want `slice q of type \*string is resized to zero length without clearing elements`
+		q = append(q, &input[0])
+		input = input[1:]
+		clear(other)
+	}
+	runtime.KeepAlive(q)
+}
+
+func _(n int) {
+	// Safe: primitive slice truncated in the post statement
+	q := []int{1}
+	for i := 0; i < n; q = q[:0] {
+		q = append(q, i)
+		i++
+	}
+	runtime.KeepAlive(q)
+}
+
+func _(kind int) {
+	// Unsafe: truncation in a switch init statement
+	s := []*int{new(int)}
+	switch s = s[:0]; kind { // want `slice s of type \*int is resized to zero length without clearing elements`
+	case 0:
+		runtime.KeepAlive(s)
+	}
+}
+
+func _(v any) {
+	// Unsafe: truncation in a type switch init statement
+	s := []*int{new(int)}
+	switch s = s[:0]; v.(type) { // want `slice s of type \*int is resized to zero length without clearing elements`
+	case int:
+		runtime.KeepAlive(s)
+	}
+}
+
+func _(kind int) {
+	// Safe: clear() directly preceding the switch statement
+	s := []*int{new(int)}
+	clear(s)
+	switch s = s[:0]; kind {
+	case 0:
+		runtime.KeepAlive(s)
+	}
+}
+
+func _(v any) {
+	// Safe: clear() directly preceding the labeled type switch statement
+	s := []*int{new(int)}
+	clear(s)
+outer:
+	switch s = s[:0]; v.(type) {
+	case int:
+		runtime.KeepAlive(s)
+		break outer
+	}
+}
+
+func _(input []string) {
+	// Unsafe: truncation in the post statement of a labeled loop
+	q := []*string{new(string)}
+loop:
+	for ; len(input) > 0; q = q[:0] { // want `slice q of type \*string is resized to zero length without clearing elements`
+		q = append(q, &input[0])
+		input = input[1:]
+		if len(input) == 1 {
+			continue loop
+		}
+	}
+	runtime.KeepAlive(q)
+}
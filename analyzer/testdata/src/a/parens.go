@@ -0,0 +1,44 @@
+package a
+
+import "runtime"
+
+func _() {
+	// Unsafe: parenthesized target and sliced expression
+	s := []*int{new(int)}
+	(s) = (s)[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: nested parentheses around the sliced expression
+	buf := []*int{new(int)}
+	buf = ((buf))[:0] // want `slice buf of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(buf)
+}
+
+func _() {
+	// Unsafe: parenthesized slice expression
+	buf := []*int{new(int)}
+	buf = (buf[:0]) // want `slice buf of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(buf)
+}
+
+func _(c *Client) {
+	// Unsafe: parentheses inside a selector chain
+	(c.state).bufs = ((c.state).bufs)[:0] // want `slice \(c.state\).bufs of type \*a.Conn is resized to zero length without clearing elements`
+}
+
+func _() {
+	// Safe: parenthesized clear() argument directly preceding length adjustment
+	s := []*int{new(int)}
+	clear((s))
+	((s)) = s[:0]
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Safe: parentheses around a primitive slice
+	s := []int{1}
+	(s) = ((s))[:0]
+	runtime.KeepAlive(s)
+}
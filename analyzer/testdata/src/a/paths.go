@@ -0,0 +1,120 @@
+package a
+
+import (
+	"log"
+	"slices"
+)
+
+func _(s []*int, verbose bool) {
+	// Safe: cleared on both branches before the shared truncation
+	if verbose {
+		log.Println("reset")
+		clear(s)
+	} else {
+		clear(s)
+	}
+	s = s[:0]
+	_ = s
+}
+
+func _(s []*int, mode int) {
+	// Safe: cleared in every case of a switch with a default
+	switch mode {
+	case 0:
+		clear(s)
+	case 1:
+		for i := range s {
+			s[i] = nil
+		}
+	default:
+		s = slices.Delete(s, 0, len(s))
+	}
+	s = s[:0]
+	_ = s
+}
+
+func _(s []*int, ok bool) {
+	// Safe: the path without a clear returns early
+	if ok {
+		clear(s)
+	} else {
+		log.Println("skip")
+		return
+	}
+	s = s[:0]
+	_ = s
+}
+
+func _(s []*int, verbose bool) {
+	// Unsafe: cleared on one branch only
+	if verbose {
+		clear(s)
+	}
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*int, mode int) {
+	// Unsafe: a switch without a default has a path without a clear
+	switch mode {
+	case 0:
+		clear(s)
+	case 1:
+		clear(s)
+	}
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*int, p *int, verbose bool) {
+	// Unsafe: one branch refills the slice after its clear
+	if verbose {
+		clear(s)
+		s = append(s, p)
+	} else {
+		clear(s)
+	}
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*int, ps []*int) {
+	// Safe: cleared on every iteration before the truncation
+	for _, p := range ps {
+		if p != nil {
+			clear(s)
+		} else {
+			clear(s)
+		}
+		s = s[:0]
+		s = append(s, p)
+	}
+	_ = s
+}
+
+func _(s []*int, ps []*int, verbose bool) {
+	// Unsafe: the append of the previous iteration reaches the truncation
+	if verbose {
+		clear(s)
+	} else {
+		clear(s)
+	}
+	for _, p := range ps {
+		s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+		s = append(s, p)
+	}
+	_ = s
+}
+
+func _(s []*int, ps []*int, verbose bool) {
+	// Safe: nothing in the loop refills the slice between the clears and the truncation
+	if verbose {
+		clear(s)
+	} else {
+		clear(s)
+	}
+	for range ps {
+		s = s[:0]
+	}
+	_ = s
+}
@@ -0,0 +1,56 @@
+package a
+
+import "runtime"
+
+type bufferSet struct {
+	bufs []*Conn
+}
+
+type Server struct {
+	bufferSet
+	name string
+}
+
+type Proxy struct {
+	*Server
+}
+
+func (s *Server) resetPromoted() {
+	// Unsafe: promoted field spelled the short way
+	s.bufs = s.bufs[:0] // want `slice s.bufs of type \*a.Conn is resized to zero length without clearing elements`
+}
+
+func (s *Server) resetMixed() {
+	// Unsafe: promoted field spelled differently on each side
+	s.bufs = s.bufferSet.bufs[:0] // want `slice s.bufs of type \*a.Conn is resized to zero length without clearing elements`
+}
+
+func (s *Server) resetMixedCleared() {
+	// Safe: clear() of the explicit spelling directly preceding truncation of the promoted spelling
+	clear(s.bufferSet.bufs)
+	s.bufs = s.bufs[:0]
+}
+
+func (p *Proxy) resetThroughEmbeddedPointer() {
+	// Unsafe: field promoted through an embedded pointer, spelled differently on each side
+	p.Server.bufferSet.bufs = p.bufs[:0] // want `slice p.Server.bufferSet.bufs of type \*a.Conn is resized to zero length without clearing elements`
+}
+
+func (s *Server) resetExplicitDereference() {
+	// Safe: an explicit dereference selects the same field as the implicit one
+	clear((*s).bufs)
+	s.bufs = s.bufs[:0]
+}
+
+func _(s []*Conn) {
+	// Safe: a new variable in an inner scope shares the spelling but not the identity of the outer one
+	if len(s) > 0 {
+		s := s[:0]
+		runtime.KeepAlive(s)
+	}
+}
+
+func _(a, b *Server) {
+	// Safe: the same field of two different values
+	a.bufs = b.bufs[:0]
+}
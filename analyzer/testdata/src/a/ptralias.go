@@ -0,0 +1,51 @@
+package a
+
+type queueState struct {
+	queue []*Token
+}
+
+func drainThroughAlias(state *queueState) {
+	// Unsafe: p only ever aliases state.queue, so the report names the slice itself
+	p := &state.queue
+	*p = (*p)[:0] // want `slice state.queue of type \*a.Token is resized to zero length without clearing elements`
+}
+
+func drainLocalThroughAlias() {
+	var pending []*Token
+	var p = &pending
+	*p = (*p)[:0] // want `slice pending of type \*a.Token is resized to zero length without clearing elements`
+}
+
+func clearThroughTarget(state *queueState) {
+	// Safe: clearing the aliased field covers the truncation through the pointer
+	p := &state.queue
+	clear(state.queue)
+	*p = (*p)[:0]
+}
+
+func clearThroughAlias(state *queueState) {
+	// Safe: clearing through the pointer covers the truncation of the field
+	p := &state.queue
+	clear(*p)
+	state.queue = state.queue[:0]
+}
+
+func reassignedAlias(a, b *queueState) {
+	// Unsafe, but p may point to either slice, so it is reported as written
+	p := &a.queue
+	p = &b.queue
+	*p = (*p)[:0] // want `slice \*p of type \*a.Token is resized to zero length without clearing elements`
+}
+
+func escapingAlias(state *queueState) *[]*Token {
+	// Unsafe, but p escapes, so it is reported as written
+	p := &state.queue
+	*p = (*p)[:0] // want `slice \*p of type \*a.Token is resized to zero length without clearing elements`
+	return p
+}
+
+func capturedAlias(state *queueState) func() {
+	p := &state.queue
+	*p = (*p)[:0] // want `slice \*p of type \*a.Token is resized to zero length without clearing elements`
+	return func() { *p = nil }
+}
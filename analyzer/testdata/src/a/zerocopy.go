@@ -0,0 +1,71 @@
+package a
+
+var zeroConns = make([]*Conn, 1024)
+
+var zeroPtrs = make([]*int, 2)
+
+var writtenZeros = make([]*Conn, 1024)
+
+func init() {
+	writtenZeros[0] = &Conn{}
+}
+
+type connPool struct {
+	conns []*Conn
+}
+
+func (p *connPool) reset() {
+	// Safe: wiped from a zero buffer before the truncation
+	copy(p.conns, zeroConns)
+	p.conns = p.conns[:0]
+}
+
+func _(s []*Conn) {
+	// Safe: a local zero buffer
+	zero := make([]*Conn, 64)
+	copy(s, zero)
+	s = s[:0]
+	_ = s
+}
+
+func _(s []*Conn) {
+	// Safe: the full-capacity spelling
+	copy(s[:cap(s)], zeroConns)
+	s = s[:0]
+	_ = s
+}
+
+func _(s []*Conn) {
+	copy(s, writtenZeros)
+	s = s[:0] // want `slice s of type \*a.Conn is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*Conn, src []*Conn) {
+	copy(s, src)
+	s = s[:0] // want `slice s of type \*a.Conn is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _() {
+	// The zero buffer is shorter than s, whose length is known.
+	s := make([]*int, 4)
+	copy(s, zeroPtrs)
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _() {
+	s := make([]*int, 2)
+	copy(s, zeroPtrs)
+	s = s[:0]
+	_ = s
+}
+
+func _(s []*Conn, n int) {
+	// A buffer made with a non-constant length is not tracked.
+	zero := make([]*Conn, n)
+	copy(s, zero)
+	s = s[:0] // want `slice s of type \*a.Conn is resized to zero length without clearing elements`
+	_ = s
+}
@@ -0,0 +1,51 @@
+package a
+
+func _() {
+	// Safe: both elements are zeroed in a tuple assignment
+	s := make([]*int, 2)
+	s[0], s[1] = nil, nil
+	s = s[:0]
+	_ = s
+}
+
+func _(x *int) {
+	// Safe: separate statements zero every element of the literal
+	s := []*int{x, x, x}
+	s[0] = nil
+	s[2] = nil
+	println("reset")
+	s[1] = nil
+	s = s[:0]
+	_ = s
+}
+
+func _() {
+	s := make([]*int, 3)
+	s[0], s[1] = nil, nil
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(x *int) {
+	s := make([]*int, 2)
+	s[0] = nil
+	s[1] = x
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(x *int) {
+	// Zeroed before the last element is refilled.
+	s := make([]*int, 2)
+	s[0], s[1] = nil, nil
+	s[1] = x
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(s []*int) {
+	// The length of a parameter is not known.
+	s[0], s[1] = nil, nil
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = s
+}
@@ -0,0 +1,50 @@
+package advance
+
+type task struct {
+	run func()
+}
+
+type fifo struct {
+	items  []*task
+	counts []int
+}
+
+func (q *fifo) pop() *task {
+	t := q.items[0]
+	// Unsafe: the popped element stays in the backing array
+	q.items = q.items[1:] // want `slice q.items of type \*advance.task is advanced to index 1 without clearing elements; the consumed elements before it remain reachable through the backing array`
+	return t
+}
+
+func (q *fifo) popBatch(n int) {
+	// Unsafe: explicit len high bound
+	q.items = q.items[n:len(q.items)] // want `slice q.items of type \*advance.task is advanced to index n without clearing elements; the consumed elements before it remain reachable through the backing array`
+}
+
+func _(s []*task) []*task {
+	// Unsafe: zero-length truncations keep their own message
+	s = s[:0] // want `slice s of type \*advance.task is resized to zero length without clearing elements`
+	return s
+}
+
+func _(s []*task) []*task {
+	// Safe: the low bound is the constant zero
+	s = s[0:]
+	return s
+}
+
+func _(s []*task, i, j int) []*task {
+	// Not an advance: the high bound drops the tail as well
+	s = s[i:j]
+	return s
+}
+
+func (q *fifo) popCount() {
+	// Safe: elements hold no references
+	q.counts = q.counts[1:]
+}
+
+func (q *fifo) popNext(next func() int) {
+	// Unsafe: the index is evaluated once by slices.Delete, but would be evaluated twice by a clear
+	q.items = q.items[next():] // want `slice q.items of type \*advance.task is advanced to index next\(\) without clearing elements; the consumed elements before it remain reachable through the backing array; clear them before advancing, or use slices.Delete\(q.items, 0, next\(\)\), which also moves the remaining elements to index 0`
+}
@@ -0,0 +1,9 @@
+package advance
+
+import sl "slices"
+
+func _(q []*task) []*task {
+	// Unsafe: the message names slices.Delete by the name the file imports it under
+	q = q[1:] // want `slice q of type \*advance.task is advanced to index 1 without clearing elements; .*, or use sl.Delete\(q, 0, 1\), which`
+	return sl.Clip(q)
+}
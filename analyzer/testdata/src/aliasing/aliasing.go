@@ -0,0 +1,48 @@
+package aliasing
+
+import "runtime"
+
+type Conn struct {
+	addr string
+}
+
+type pool struct {
+	buf  []*Conn
+	nums []int
+}
+
+func _(p *pool) {
+	// Unsafe: a new variable aliases the backing array of a long-lived field
+	scratch := p.buf[:0] // want `scratch aliases the backing array of slice p.buf of type \*aliasing.Conn; the old elements remain reachable through p.buf`
+	runtime.KeepAlive(scratch)
+}
+
+func _(p *pool) {
+	// Unsafe: plain assignment to a different variable
+	var scratch []*Conn
+	scratch = p.buf[:0] // want `scratch aliases the backing array of slice p.buf of type \*aliasing.Conn; the old elements remain reachable through p.buf`
+	runtime.KeepAlive(scratch)
+}
+
+func _(p *pool) {
+	// Safe: the aliased slice's elements are primitive
+	scratch := p.nums[:0]
+	runtime.KeepAlive(scratch)
+}
+
+func _(p *pool) {
+	// Safe: the blank identifier keeps nothing alive
+	_ = p.buf[:0]
+}
+
+func _(p *pool) {
+	// Safe: aliasing a non-empty view is ordinary slicing
+	head := p.buf[:1]
+	runtime.KeepAlive(head)
+}
+
+func _(n int) {
+	// Safe: a freshly allocated backing array is not shared with anything
+	scratch := make([]*Conn, n)[:0]
+	runtime.KeepAlive(scratch)
+}
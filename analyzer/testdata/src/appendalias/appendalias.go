@@ -0,0 +1,47 @@
+package appendalias
+
+type Item struct{ ok bool }
+
+type Index struct {
+	items []*Item
+}
+
+func filterThenRead(items []*Item) ([]*Item, int) {
+	kept := append(items[:0], items[1:]...) // want `kept is built with append\(items\[:0\], ...\), which overwrites the elements of items in its backing array while items is still used later; append into slices.Clone\(items\)\[:0\] or a separate buffer`
+	return kept, len(items)
+}
+
+func fields(ix *Index, extra *Item) {
+	var first []*Item
+	first = append(ix.items[:0], extra) // want `first is built with append\(ix.items\[:0\], ...\)`
+	for _, it := range ix.items {
+		_ = it
+	}
+	_ = first
+}
+
+func ids(src []int, out *[]int) {
+	*out = append(src[:0], 1, 2) // want `\*out is built with append\(src\[:0\], ...\)`
+	_ = src[0]
+}
+
+func filterInPlace(items []*Item) []*Item {
+	kept := append(items[:0], items[0])
+	return kept
+}
+
+func overwritten(items []*Item, fresh []*Item) []*Item {
+	kept := append(items[:0], items[0])
+	items = fresh
+	return append(kept, items...)
+}
+
+func selfReuse(items []*Item) []*Item {
+	items = append(items[:0], nil)
+	return items
+}
+
+func discarded(items []*Item) int {
+	_ = append(items[:0], nil)
+	return len(items)
+}
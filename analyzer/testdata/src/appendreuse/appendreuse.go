@@ -0,0 +1,53 @@
+package appendreuse
+
+type span struct {
+	attrs map[string]string
+}
+
+type exporter struct {
+	pending []*span
+}
+
+func (e *exporter) collect(batch []*span) {
+	// Unsafe: the batch may be shorter than the previous contents
+	e.pending = append(e.pending[:0], batch...) // want `slice e.pending of type \*appendreuse.span is refilled with append\(e.pending\[:0\], ...\) without clearing elements; elements beyond the new length remain reachable if the refill is shorter, so clear\(e.pending\) first or use slices.Grow and copy`
+}
+
+func _(a, b, c *span) {
+	// Unsafe: refilled with fewer elements than the slice was made with
+	buf := make([]*span, 4)
+	buf = append(buf[:0], a, b, c) // want `slice buf of type \*appendreuse.span is refilled with append\(buf\[:0\], ...\) without clearing elements`
+	_ = buf
+}
+
+func _(a, b, c *span) {
+	// Safe: refilled with at least as many elements as the slice ever held
+	buf := []*span{a, b}
+	buf = append(buf[:0], a, b, c)
+	buf = append(buf[:0], []*span{c, b, a}...)
+	_ = buf
+}
+
+func _(buf []*span, a *span) []*span {
+	// Unsafe: the previous length of a parameter is unknown
+	buf = append(buf[:0], a) // want `slice buf of type \*appendreuse.span is refilled with append\(buf\[:0\], ...\) without clearing elements`
+	return buf
+}
+
+func (e *exporter) collectCleared(batch []*span) {
+	// Safe: cleared before the refill
+	clear(e.pending)
+	e.pending = append(e.pending[:0], batch...)
+}
+
+func _(buf []*span) []*span {
+	// Not a refill: removal of the first element, reported by the main checks
+	buf = append(buf[:0], buf[1:]...) // want `slice buf of type \*appendreuse.span has elements removed with append`
+	return buf
+}
+
+func _(ids, more []int) []int {
+	// Safe: elements hold no references
+	ids = append(ids[:0], more...)
+	return ids
+}
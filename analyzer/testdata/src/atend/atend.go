@@ -0,0 +1,53 @@
+package atend
+
+type frame struct {
+	data []byte
+}
+
+type decoder struct {
+	frames []*frame
+}
+
+func (d *decoder) discard() {
+	// Unsafe: emptied from the end, every old element stays behind
+	d.frames = d.frames[len(d.frames):] // want `slice d.frames of type \*atend.frame is resized to zero length at index len\(d.frames\) without clearing elements; the result cannot reuse the capacity before that index either`
+}
+
+func _(buf []*frame) []*frame {
+	// Unsafe: explicit high bound
+	buf = buf[len(buf):len(buf)] // want `slice buf of type \*atend.frame is resized to zero length at index len\(buf\) without clearing elements; the result cannot reuse the capacity before that index either`
+	return buf
+}
+
+func _(buf []*frame, n int) []*frame {
+	// Unsafe: empty at a variable index
+	buf = buf[n:n] // want `slice buf of type \*atend.frame is resized to zero length at index n without clearing elements; the result cannot reuse the capacity before that index either`
+	return buf
+}
+
+func _(buf []*frame) []*frame {
+	// Unsafe, but there is no place for a clear in the header, so no fix is suggested
+	if buf = buf[len(buf):]; len(buf) == 0 { // want `slice buf of type \*atend.frame is resized to zero length at index len\(buf\) without clearing elements; the result cannot reuse the capacity before that index either`
+		return nil
+	}
+	return buf
+}
+
+func _(buf []*frame) []*frame {
+	// Safe: cleared before the reslice
+	clear(buf)
+	buf = buf[len(buf):]
+	return buf
+}
+
+func _(buf []*frame, next func() int) []*frame {
+	// Not provably empty: the bounds may differ
+	buf = buf[next():next()]
+	return buf
+}
+
+func _(b []byte) []byte {
+	// Safe: elements hold no references
+	b = b[len(b):]
+	return b
+}
@@ -0,0 +1,14 @@
+package badtemplate
+
+type Conn struct{}
+
+func truncate(conns []*Conn) []*Conn {
+	conns = conns[:0] // want `slice conns of type \*badtemplate.Conn is resized to zero length without clearing elements` `-fix-template cannot be used, so no fix calls it: output "conns =" is not a Go statement`
+	return conns
+}
+
+func again(conns []*Conn) []*Conn {
+	// The template is only reported once.
+	conns = conns[:0] // want `slice conns of type \*badtemplate.Conn is resized to zero length without clearing elements`
+	return conns
+}
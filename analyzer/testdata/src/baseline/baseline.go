@@ -0,0 +1,27 @@
+package baseline
+
+type Conn struct{ id int }
+
+func known(s []*Conn) []*Conn {
+	// Known findings are not reported, wherever they moved to.
+	s = s[:0]
+	return s
+}
+
+func knownTwice(s, t []*Conn) ([]*Conn, []*Conn) {
+	s = s[:0]
+	t = t[:0]
+	return s, t
+}
+
+func duplicated(s []*Conn) []*Conn {
+	// The baseline lists this line once, so only its first copy is known.
+	s = s[:0]
+	s = s[:0] // want `slice s of type \*baseline.Conn is resized to zero length without clearing elements`
+	return s
+}
+
+func edited(s []*Conn) []*Conn {
+	s = s[:0] // want `slice s of type \*baseline.Conn is resized to zero length without clearing elements`
+	return s
+}
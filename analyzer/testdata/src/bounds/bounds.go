@@ -0,0 +1,36 @@
+package bounds
+
+import "slices"
+
+type node struct{ next *node }
+
+type queue struct {
+	items []*node
+}
+
+func capacity(ints []int, q *queue) []int {
+	ints = slices.Delete(ints, 0, cap(ints))          // want `slices.Delete with end index cap\(ints\) panics when the capacity of ints exceeds its length; use len\(ints\)`
+	q.items = slices.Delete(q.items, 1, cap(q.items)) // want `end index cap\(q.items\) panics`
+	return ints
+}
+
+func instantiated(nodes []*node) []*node {
+	return slices.Delete[[]*node](nodes, 0, cap(nodes)) // want `end index cap\(nodes\) panics`
+}
+
+func constant() []*node {
+	nodes := make([]*node, 3)
+	nodes = slices.Delete(nodes, 0, 8) // want `slices.Delete with end index 8 panics: nodes holds at most 3 elements; use len\(nodes\)`
+	nodes = append(nodes[:0], nil, nil)
+	return slices.Delete(nodes, 0, 3)
+}
+
+func unknown(nodes []*node, other []*node) []*node {
+	nodes = slices.Delete(nodes, 0, 8)
+	nodes = slices.Delete(nodes, 0, len(nodes))
+	return slices.Delete(nodes, 0, cap(other))
+}
+
+func unnamed(f func() []int) []int {
+	return slices.Delete(f(), 0, cap(f())) // want `end index cap\(f\(\)\) panics`
+}
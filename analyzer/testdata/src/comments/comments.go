@@ -0,0 +1,34 @@
+package comments
+
+type Conn struct{}
+
+type pool struct {
+	conns []*Conn
+	byKey map[string][]*Conn
+}
+
+func (p *pool) trailing() {
+	p.conns = p.conns[:0] // want `slice p.conns of type \*comments.Conn is resized to zero length without clearing elements`
+}
+
+func (p *pool) leading() {
+	// Keep the backing array for the next batch.
+	p.conns = p.conns[:0] // want `slice p.conns of type \*comments.Conn is resized to zero length without clearing elements`
+}
+
+func (p *pool) interior() {
+	p.conns = p.conns[: /* empty */ 0] // want `slice p.conns of type \*comments.Conn is resized to zero length without clearing elements`
+}
+
+func (p *pool) remove(i int) {
+	// Shift the tail down by one.
+	copy(p.conns[i:], p.conns[i+1:]) // overlapping is fine
+	// Drop the last slot.
+	p.conns = p.conns[:len(p.conns)-1] // want `slice p.conns of type \*comments.Conn has elements shifted out with copy`
+}
+
+func (p *pool) resetKey(k string) {
+	v := p.byKey[k] // the current batch
+	v = v[:0]       // want `slice p.byKey\[k\] of type \*comments.Conn is resized to zero length through v without clearing elements`
+	p.byKey[k] = v  // store it back
+}
@@ -0,0 +1,43 @@
+package conversion
+
+type Block struct {
+	data []byte
+}
+
+type Buf []*Block
+
+type pool struct {
+	free Buf
+}
+
+func toBlocks(b Buf) []*Block { return b }
+
+func _(s []*Block) []*Block {
+	// Unsafe: conversion to a defined slice type shares the backing array
+	s = Buf(s)[:0] // want `slice s of type \*conversion.Block is resized to zero length without clearing elements`
+	return s
+}
+
+func _(b Buf) Buf {
+	// Unsafe: conversion to the underlying slice type
+	b = []*Block(b)[:0] // want `slice b of type \*conversion.Block is resized to zero length without clearing elements`
+	return b
+}
+
+func _(p *pool) {
+	// Unsafe: parenthesized conversion of a field
+	p.free = (Buf)([]*Block(p.free))[:0] // want `slice p.free of type \*conversion.Block is resized to zero length without clearing elements`
+}
+
+func _(b Buf) Buf {
+	// Safe: cleared before the converted truncation
+	clear(b)
+	b = []*Block(b)[:0]
+	return b
+}
+
+func _(b Buf) []*Block {
+	// Not a truncation of b: toBlocks is a function call, not a conversion
+	b = toBlocks(b)[:0]
+	return b
+}
@@ -0,0 +1,98 @@
+package copytail
+
+import "sync"
+
+type Event struct{ payload []byte }
+
+type Stage struct {
+	events []*Event
+	ids    []int
+}
+
+var pool = sync.Pool{New: func() any { return new([]*Event) }}
+
+func (s *Stage) Load(src []*Event) int {
+	// The other methods read s.events beyond n, so the fix is to be verified
+	n := copy(s.events, src) // want `copy into reused buffer s.events of type \*copytail.Event leaves s.events\[n:\] reachable when the source is shorter; clear it with clear\(s.events\[n:\]\) or truncate s.events to n`
+	return n
+}
+
+func (s *Stage) LoadPool(src []*Event) {
+	// Nothing reads the buffer beyond count
+	bp := pool.Get().(*[]*Event)
+	var count int
+	count = copy(*bp, src) // want `copy into reused buffer \(\*bp\) of type \*copytail.Event leaves \(\*bp\)\[count:\] reachable`
+	_ = count
+}
+
+func (s *Stage) Truncated(src []*Event) {
+	n := copy(s.events, src)
+	s.events = s.events[:n]
+}
+
+func (s *Stage) Cleared(src []*Event) {
+	n := copy(s.events, src)
+	if n < len(s.events) {
+		clear(s.events[n:])
+	}
+}
+
+func (s *Stage) Primitive(src []int) int {
+	n := copy(s.ids, src)
+	return n
+}
+
+func local(dst, src []*Event) int {
+	n := copy(dst, src)
+	return n
+}
+
+func (s *Stage) LoadPut(src []*Event) {
+	// The buffer goes back into the pool, whose next user may read it beyond n
+	bp := pool.Get().(*[]*Event)
+	n := copy(*bp, src) // want `copy into reused buffer \(\*bp\) of type \*copytail.Event leaves \(\*bp\)\[n:\] reachable`
+	s.events = append(s.events, (*bp)[:n]...)
+	pool.Put(bp) // want `slice \(\*bp\) of type \*copytail.Event is put into a sync.Pool without clearing elements`
+}
+
+func (s *Stage) Discarded(src []*Event) {
+	// The count is discarded, so the copy moves into the clear
+	copy(s.events, src) // want `copy into reused buffer s.events of type \*copytail.Event discards the copied count and leaves the elements beyond it reachable when the source is shorter; clear them with clear\(s.events\[copy\(s.events, src\):\]\)`
+}
+
+func (s *Stage) DiscardedCall(next func() []*Event) {
+	// No fix: next might change s.events before or after it is evaluated again
+	_ = copy(s.events, next()) // want `copy into reused buffer s.events of type \*copytail.Event discards the copied count`
+}
+
+func (s *Stage) Overwritten(src, more []*Event) {
+	// Safe: the later copy and truncation handle the tail
+	copy(s.events, src)
+	n := copy(s.events, more)
+	s.events = s.events[:n]
+}
+
+// Window is only read up to the count of its last copy.
+type Window struct {
+	slots []*Event
+	n     int
+}
+
+func (w *Window) Fill(src []*Event) []*Event {
+	n := copy(w.slots, src) // want `copy into reused buffer w.slots of type \*copytail.Event leaves w.slots\[n:\] reachable`
+	w.n = n
+	return w.slots[:n]
+}
+
+func (w *Window) Len() int {
+	return len(w.slots)
+}
+
+// Sink is only ever written.
+type Sink struct {
+	items []*Event
+}
+
+func (k *Sink) Store(src []*Event) {
+	_ = copy(k.items, src[1:]) // want `copy into reused buffer k.items of type \*copytail.Event discards the copied count`
+}
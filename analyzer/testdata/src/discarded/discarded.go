@@ -0,0 +1,31 @@
+package discarded
+
+import "slices"
+
+type item struct {
+	name string
+}
+
+type list struct {
+	items []*item
+}
+
+func (l *list) reset() {
+	// Unsafe: the shortened slice is thrown away
+	slices.Delete(l.items, 0, len(l.items)) // want `result of slices.Delete is discarded; the shortened slice is lost and l.items keeps its old length`
+}
+
+func (l *list) removeNamed(name string) {
+	// Unsafe: DeleteFunc with an explicit instantiation
+	slices.DeleteFunc[[]*item](l.items, func(it *item) bool { return it.name == name }) // want `result of slices.DeleteFunc is discarded; the shortened slice is lost and l.items keeps its old length`
+}
+
+func _(s []int, n int) {
+	// Unsafe: the element type does not matter, but a reslice cannot be assigned back
+	slices.Delete(s[:n], 0, 1) // want `result of slices.Delete is discarded; the shortened slice is lost and s\[:n\] keeps its old length`
+}
+
+func (l *list) resetAssigned() {
+	// Safe: the result is assigned back
+	l.items = slices.Delete(l.items, 0, len(l.items))
+}
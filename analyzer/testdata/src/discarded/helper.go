@@ -0,0 +1,13 @@
+package discarded
+
+type helpers struct{}
+
+// Delete has the same name as slices.Delete but is a different function.
+func (helpers) Delete(s []*item, i, j int) []*item { return s }
+
+var slicesHelper helpers
+
+func _(s []*item) {
+	// Safe: not the slices package function
+	slicesHelper.Delete(s, 0, len(s))
+}
@@ -0,0 +1,47 @@
+package elemaddr
+
+type Record struct {
+	id   int
+	name string
+}
+
+type Cache struct {
+	last   *Record
+	window []Record
+}
+
+var latest *Record
+
+func load(c *Cache, n int) {
+	records := make([]Record, 0, 8192)
+	for i := 0; i < n; i++ {
+		records = append(records, Record{id: i})
+	}
+	c.last = &records[n-1]      // want `pointer to an element of records \(made with a large constant size\) stored in c.last keeps its entire backing array reachable; copy the element into a new variable and take its address instead`
+	c.window = records[n-1 : n] // want `single-element subslice of records \(made with a large constant size\) stored in c.window keeps its entire backing array reachable; store a copy with slices.Clone`
+	latest = &records[0]        // want `stored in latest`
+}
+
+func grown(src []int) (*Record, Cache) {
+	var records []Record
+	for _, id := range src {
+		records = append(records, Record{id: id})
+	}
+	if len(records) == 0 {
+		return nil, Cache{}
+	}
+	return &records[0], Cache{window: records[2:3]} // want `pointer to an element of records \(grown by append in a loop\) returned` `single-element subslice of records \(grown by append in a loop\) stored in field window of a Cache literal`
+}
+
+func small(c *Cache) *Record {
+	records := make([]Record, 16)
+	c.last = &records[0]
+	c.window = records[0:2]
+	return &records[1]
+}
+
+func local(n int) int {
+	records := make([]Record, 8192)
+	first := &records[0]
+	return first.id + n
+}
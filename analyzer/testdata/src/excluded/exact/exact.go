@@ -0,0 +1,6 @@
+package exact
+
+func reset(s []*int) []*int {
+	s = s[:0]
+	return s
+}
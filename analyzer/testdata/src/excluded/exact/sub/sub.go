@@ -0,0 +1,6 @@
+package sub
+
+func reset(s []*int) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return s
+}
@@ -0,0 +1,6 @@
+package deep
+
+func reset(s []*int) []*int {
+	s = s[:0]
+	return s
+}
@@ -0,0 +1,6 @@
+package legacy
+
+func reset(s []*int) []*int {
+	s = s[:0]
+	return s
+}
@@ -0,0 +1,29 @@
+package expslices
+
+import (
+	"slices"
+
+	xslices "golang.org/x/exp/slices"
+)
+
+type Conn struct{ id int }
+
+func aliased(s []*Conn) []*Conn {
+	// Safe: already cleared by the x/exp Delete, which calls the standard library one
+	s = xslices.Delete(s, 0, len(s))
+	s = s[:0]
+	return s
+}
+
+func stdlib(s []*Conn) []*Conn {
+	// Safe: the standard library works the same
+	s = slices.Delete(s, 0, len(s))
+	s = s[:0]
+	return s
+}
+
+func partial(s []*Conn) []*Conn {
+	s = xslices.Delete(s, 0, 1)
+	s = s[:0] // want `slice s of type \*expslices.Conn is resized to zero length without clearing elements`
+	return s
+}
@@ -0,0 +1,10 @@
+package expslices
+
+import "golang.org/x/exp/slices"
+
+func unaliased(s []*Conn) []*Conn {
+	// Safe: imported under its own name
+	s = slices.Delete(s, 0, len(s))
+	s = s[:0]
+	return s
+}
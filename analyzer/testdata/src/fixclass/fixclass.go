@@ -0,0 +1,35 @@
+package fixclass
+
+func unaliased(s []*int) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return s
+}
+
+func discarded(s []*int) []*int {
+	_ = s[:1]
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return s
+}
+
+// The elements cleared by the fixes are still visible through head.
+func aliased(s []*int) ([]*int, []*int) {
+	head := s[:1]
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return head, s
+}
+
+func aliasedInClosure(s []*int) (func() *int, []*int) {
+	get := func() *int {
+		p := &s
+		return (*p)[0]
+	}
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return get, s
+}
+
+// Moving the clear reorders statements.
+func reordered(s []*int) []*int {
+	s = s[:0]
+	clear(s) // want `clear\(s\) after truncating s to zero length has no effect`
+	return s
+}
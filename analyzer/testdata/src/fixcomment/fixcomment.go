@@ -0,0 +1,20 @@
+package fixcomment
+
+type Pool struct {
+	idle []*int
+}
+
+func (p *Pool) drain() {
+	p.idle = p.idle[:0] // want `slice p.idle of type \*int is resized to zero length without clearing elements`
+}
+
+func tuple(a, b []*int) ([]*int, []*int) {
+	a, b = a[:0], b // want `slice a of type \*int is resized to zero length without clearing elements`
+	return a, b
+}
+
+func moved(s []*int) []*int {
+	s = s[:0]
+	clear(s) // want `clear\(s\) after truncating s to zero length has no effect`
+	return s
+}
@@ -0,0 +1,25 @@
+// Package fixstyle holds truncations offered both the slices.Delete and the clear-style fix.
+package fixstyle
+
+type Conn struct{}
+
+type pool struct {
+	idle []*Conn
+}
+
+func (p *pool) drain() {
+	if p.idle != nil {
+		p.idle = p.idle[:0] // want `slice p.idle of type \*fixstyle.Conn is resized to zero length without clearing elements`
+	}
+}
+
+func reset(conns []*Conn) []*Conn {
+	conns = conns[:0:cap(conns)] // want `slice conns of type \*fixstyle.Conn is resized to zero length without clearing elements`
+	return conns
+}
+
+func swap(a, b []*Conn) ([]*Conn, []*Conn) {
+	// Only the slices.Delete fix rewrites a pair of a tuple assignment
+	a, b = a[:0], b // want `slice a of type \*fixstyle.Conn is resized to zero length without clearing elements`
+	return a, b
+}
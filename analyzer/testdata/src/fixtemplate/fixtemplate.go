@@ -0,0 +1,31 @@
+package fixtemplate
+
+import "strings"
+
+type Conn struct{}
+
+type pool struct {
+	conns []*Conn
+	names []*strings.Builder
+}
+
+func (p *pool) reset() {
+	// Truncations are routed through the helper of -fix-template.
+	p.conns = p.conns[:0] // want `slice p.conns of type \*fixtemplate.Conn is resized to zero length without clearing elements`
+}
+
+func truncate(conns []*Conn) []*Conn {
+	conns = conns[:0] // want `slice conns of type \*fixtemplate.Conn is resized to zero length without clearing elements`
+	return conns
+}
+
+func (p *pool) pair(other []*Conn) []*Conn {
+	// A tuple assignment cannot be replaced as a whole, so no fix is suggested.
+	p.names, other = p.names[:0], other // want `slice p.names of type \*strings.Builder is resized to zero length without clearing elements`
+	return other
+}
+
+func keepCap(conns []*Conn) []*Conn {
+	conns = conns[:0:0] // want `slice conns of type \*fixtemplate.Conn is resized to zero length without clearing elements`
+	return conns
+}
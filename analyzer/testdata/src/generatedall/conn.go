@@ -0,0 +1,11 @@
+package generatedall
+
+type Conn struct{ id int }
+
+type Msg struct {
+	Conns []*Conn
+}
+
+func (m *Msg) Truncate() {
+	m.Conns = m.Conns[:0] // want `slice m.Conns of type \*generatedall.Conn is resized to zero length without clearing elements`
+}
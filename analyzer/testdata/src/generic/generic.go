@@ -0,0 +1,60 @@
+package generic
+
+import (
+	"constraints"
+	"fmt"
+)
+
+type Ring[T any] struct {
+	items []T
+}
+
+func (r *Ring[T]) Reset() {
+	// Unsafe: an unconstrained element type may hold references
+	r.items = r.items[:0] // want `slice r.items of type T is resized to zero length without clearing elements \(based on the constraint any of type parameter T\) \(this method is a reuse point; retained elements survive until the next fill\)`
+}
+
+type Counters[T constraints.Integer] struct {
+	items []T
+}
+
+func (c *Counters[T]) Reset() {
+	// Safe: every type admitted by the constraint is an integer
+	c.items = c.items[:0]
+}
+
+func truncatePointers[T ~*int | ~*string](s []T) []T {
+	// Unsafe: the constraint only admits pointer types
+	s = s[:0] // want `slice s of type T is resized to zero length without clearing elements \(based on the constraint ~\*int \| ~\*string of type parameter T\)`
+	return s
+}
+
+func truncateMixed[T int | string](s []T) []T {
+	// Unsafe: one of the terms of the constraint holds references
+	s = s[:0] // want `slice s of type T is resized to zero length without clearing elements \(based on the constraint int \| string of type parameter T\)`
+	return s
+}
+
+func truncateStringers[T fmt.Stringer](s []T) []T {
+	// Unsafe: a constraint with only methods admits reference types
+	s = s[:0] // want `slice s of type T is resized to zero length without clearing elements \(based on the constraint fmt.Stringer of type parameter T\)`
+	return s
+}
+
+type pair[T any] struct {
+	key   int
+	value T
+}
+
+func truncatePairs[T any](s []pair[T]) []pair[T] {
+	// Unsafe: a struct element with a field of type parameter type
+	s = s[:0] // want `slice s of type generic.pair\[T\] is resized to zero length without clearing elements`
+	return s
+}
+
+func truncateIntegers[T constraints.Integer](s []T) []T {
+	// Safe: the constraint only admits integer types
+	clear(s)
+	s = s[:0]
+	return s
+}
@@ -0,0 +1,24 @@
+package genericstrict
+
+import "fmt"
+
+type Ring[T any] struct {
+	items []T
+}
+
+func (r *Ring[T]) Reset() {
+	// Safe in strict mode: nothing is known about an unconstrained element type
+	r.items = r.items[:0]
+}
+
+func truncateStringers[T fmt.Stringer](s []T) []T {
+	// Safe in strict mode: a constraint with only methods has no type terms
+	s = s[:0]
+	return s
+}
+
+func truncatePointers[T ~*int | ~*string](s []T) []T {
+	// Unsafe: the constraint explicitly admits pointer types
+	s = s[:0] // want `slice s of type T is resized to zero length without clearing elements \(based on the constraint ~\*int \| ~\*string of type parameter T\)`
+	return s
+}
@@ -0,0 +1,10 @@
+// Package slices is a stub of golang.org/x/exp/slices for the tests. Like the real package, whose Delete calls the
+// one of the standard library from Go 1.21, its Delete zeroes the elements it removes.
+package slices
+
+func Delete[S ~[]E, E any](s S, i, j int) S {
+	oldlen := len(s)
+	s = append(s[:i], s[j:]...)
+	clear(s[len(s):oldlen])
+	return s
+}
@@ -0,0 +1,99 @@
+package heappop
+
+type Item struct {
+	value string
+	index int
+}
+
+// PQ is a priority queue as in the container/heap example, without the zeroing line.
+type PQ []*Item
+
+func (pq PQ) Len() int           { return len(pq) }
+func (pq PQ) Less(i, j int) bool { return pq[i].index < pq[j].index }
+func (pq PQ) Swap(i, j int)      { pq[i], pq[j] = pq[j], pq[i] }
+func (pq *PQ) Push(x any)        { *pq = append(*pq, x.(*Item)) }
+
+func (pq *PQ) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	item.index = -1
+	*pq = old[0 : n-1] // want `Pop method of heap type \*PQ leaves the popped element of type \*heappop.Item reachable through old\[n-1\]; set old\[n-1\] = nil before shrinking, as the container/heap example does`
+	return item
+}
+
+// SafePQ zeroes the slot like the container/heap example.
+type SafePQ []*Item
+
+func (pq SafePQ) Len() int           { return len(pq) }
+func (pq SafePQ) Less(i, j int) bool { return pq[i].index < pq[j].index }
+func (pq SafePQ) Swap(i, j int)      { pq[i], pq[j] = pq[j], pq[i] }
+func (pq *SafePQ) Push(x any)        { *pq = append(*pq, x.(*Item)) }
+
+func (pq *SafePQ) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*pq = old[0 : n-1]
+	return item
+}
+
+// IntHeap holds no references.
+type IntHeap []int
+
+func (h IntHeap) Len() int           { return len(h) }
+func (h IntHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h IntHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *IntHeap) Push(x any)        { *h = append(*h, x.(int)) }
+
+func (h *IntHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
+// Stack is not a heap, so its Pop is left alone.
+type Stack []*Item
+
+func (s *Stack) Pop() any {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	*s = old[:n-1]
+	return x
+}
+
+// FieldHeap keeps its elements in a field.
+type FieldHeap struct{ items []*Item }
+
+func (h *FieldHeap) Len() int           { return len(h.items) }
+func (h *FieldHeap) Less(i, j int) bool { return h.items[i].index < h.items[j].index }
+func (h *FieldHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *FieldHeap) Push(x any)         { h.items = append(h.items, x.(*Item)) }
+
+func (h *FieldHeap) Pop() any {
+	n := len(h.items)
+	x := h.items[n-1]
+	h.items = h.items[:n-1] // want `Pop method of heap type \*FieldHeap leaves the popped element of type \*heappop.Item reachable through h.items\[n-1\]`
+	return x
+}
+
+// ParenPQ zeroes the slot with the index spelled differently.
+type ParenPQ []*Item
+
+func (pq ParenPQ) Len() int           { return len(pq) }
+func (pq ParenPQ) Less(i, j int) bool { return pq[i].index < pq[j].index }
+func (pq ParenPQ) Swap(i, j int)      { pq[i], pq[j] = pq[j], pq[i] }
+func (pq *ParenPQ) Push(x any)        { *pq = append(*pq, x.(*Item)) }
+
+func (pq *ParenPQ) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[(n - 1)] = nil
+	*pq = old[0 : n-1]
+	return item
+}
@@ -0,0 +1,64 @@
+package helpers
+
+type conn struct {
+	read, write []*Conn
+	state       struct{ pending []*Conn }
+}
+
+func (c *conn) clearBufs() {
+	clear(c.read)
+	clear(c.write)
+}
+
+func (c *conn) clearRead() { clear(c.read) }
+
+func (c *conn) clearPending() {
+	for i := range c.state.pending {
+		c.state.pending[i] = nil
+	}
+}
+
+// clearAll clears through other helpers.
+func (x *conn) clearAll() {
+	x.clearBufs()
+	x.clearPending()
+}
+
+// wipeConn clears a field of its parameter through a method.
+func wipeConn(c *conn) {
+	c.clearRead()
+}
+
+func (c *conn) reset() {
+	c.clearBufs()
+	c.read = c.read[:0]
+	c.write = c.write[:0]
+}
+
+func (c *conn) resetRead() {
+	c.clearRead()
+	c.read = c.read[:0]
+	c.write = c.write[:0] // want `slice c.write of type \*helpers.Conn is resized to zero length without clearing elements`
+}
+
+func resetAll(cn *conn) {
+	cn.clearAll()
+	cn.read = cn.read[:0]
+	cn.state.pending = cn.state.pending[:0]
+}
+
+func resetPending(cn *conn) {
+	cn.clearBufs()
+	cn.state.pending = cn.state.pending[:0] // want `slice cn.state.pending of type \*helpers.Conn is resized to zero length without clearing elements`
+}
+
+func resetOtherConn(a, b *conn) {
+	a.clearBufs()
+	b.read = b.read[:0] // want `slice b.read of type \*helpers.Conn is resized to zero length without clearing elements`
+}
+
+func resetWiped(cn *conn) {
+	// Only the method clears fields; the fields of a parameter are not tracked through functions.
+	wipeConn(cn)
+	cn.read = cn.read[:0] // want `slice cn.read of type \*helpers.Conn is resized to zero length without clearing elements`
+}
@@ -0,0 +1,93 @@
+package helpers
+
+import (
+	"log"
+	"slices"
+)
+
+type Conn struct{ id int }
+
+type Pool struct {
+	conns []*Conn
+	idle  []*Conn
+}
+
+func wipe(s []*Conn) { clear(s) }
+
+func wipeLoop(s []*Conn) {
+	for i := range s {
+		s[i] = nil
+	}
+}
+
+func wipeDelete(label string, s []*Conn) {
+	log.Println("wiping", label)
+	s = slices.Delete(s, 0, len(s))
+	_ = s
+}
+
+// wipeMaybe clears only if asked to.
+func wipeMaybe(s []*Conn, ok bool) {
+	if !ok {
+		return
+	}
+	clear(s)
+}
+
+// wipeTail clears a different part of the slice.
+func wipeTail(s []*Conn) {
+	s = s[1:]
+	clear(s)
+}
+
+func (p *Pool) wipeConns() { clear(p.conns) }
+
+func (p Pool) wipeIdle() { clear(p.idle[:cap(p.idle)]) }
+
+func reset(pool *Pool) {
+	wipe(pool.conns)
+	pool.conns = pool.conns[:0]
+}
+
+func resetLoop(pool *Pool) {
+	wipeLoop(pool.conns)
+	pool.conns = pool.conns[:0]
+}
+
+func resetDelete(pool *Pool) {
+	wipeDelete("conns", pool.conns)
+	pool.conns = pool.conns[:0]
+}
+
+func resetOther(pool *Pool) {
+	wipe(pool.idle)
+	pool.conns = pool.conns[:0] // want `slice pool.conns of type \*helpers.Conn is resized to zero length without clearing elements`
+}
+
+func resetMaybe(pool *Pool) {
+	wipeMaybe(pool.conns, true)
+	pool.conns = pool.conns[:0] // want `slice pool.conns of type \*helpers.Conn is resized to zero length without clearing elements`
+}
+
+func resetTail(pool *Pool) {
+	wipeTail(pool.conns)
+	pool.conns = pool.conns[:0] // want `slice pool.conns of type \*helpers.Conn is resized to zero length without clearing elements`
+}
+
+func (p *Pool) Reset() { // want Reset:`ClearsArgs\(params=\[\], fields=\[conns idle\]\)`
+	p.wipeConns()
+	p.conns = p.conns[:0]
+	p.wipeIdle()
+	p.idle = p.idle[:0]
+}
+
+func resetFields(pool *Pool) {
+	pool.wipeConns()
+	pool.idle = pool.idle[:0] // want `slice pool.idle of type \*helpers.Conn is resized to zero length without clearing elements`
+	pool.conns = pool.conns[:0]
+}
+
+func resetOtherPool(a, b *Pool) {
+	a.wipeConns()
+	b.conns = b.conns[:0] // want `slice b.conns of type \*helpers.Conn is resized to zero length without clearing elements`
+}
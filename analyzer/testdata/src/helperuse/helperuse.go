@@ -0,0 +1,30 @@
+package helperuse
+
+import "sliceutil"
+
+func resetWipe(buf []*int) []*int {
+	sliceutil.Wipe(buf)
+	buf = buf[:0]
+	return buf
+}
+
+func resetBoth(a, b []*int) ([]*int, []*int) {
+	sliceutil.WipeBoth(a, "both", b)
+	a = a[:0]
+	b = b[:0]
+	return a, b
+}
+
+func resetTruncate(buf []*int) []*int {
+	sliceutil.Truncate(buf)
+	buf = buf[:0] // want `slice buf of type \*int is resized to zero length without clearing elements`
+	return buf
+}
+
+func resetBuffer(b *sliceutil.Buffer, other []*int) {
+	b.Wipe()
+	b.Items = b.Items[:0]
+	sliceutil.WipeBoth(other, "", nil)
+	other = other[:0]
+	_ = other
+}
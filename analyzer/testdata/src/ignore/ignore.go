@@ -0,0 +1,61 @@
+package ignore
+
+type Conn struct{ id int }
+
+type Pool struct {
+	conns []*Conn
+}
+
+func sameLine(s []*Conn) []*Conn {
+	s = s[:0] //clearslice:ignore CS001 -- reused buffer cleared by caller
+	return s
+}
+
+func lineAbove(s []*Conn) []*Conn {
+	//clearslice:ignore truncation -- the category works as well
+	s = s[:0]
+	return s
+}
+
+func allChecks(s []*Conn) []*Conn {
+	s = s[:0] //clearslice:ignore -- every check
+	return s
+}
+
+// Reset keeps its connections for the next user.
+//
+//clearslice:ignore CS002,CS001 -- the whole method
+func (p *Pool) Reset() {
+	p.conns = p.conns[:0]
+}
+
+func aliasing(p *Pool) []*Conn {
+	conns := p.conns[:0] //clearslice:ignore CS025 -- p.conns is cleared on release
+	return conns
+}
+
+func otherCheck(s []*Conn) []*Conn {
+	s = s[:0] //clearslice:ignore CS007 -- a different check // want `slice s of type \*ignore.Conn is resized to zero length without clearing elements`
+	return s
+}
+
+func noReason(s []*Conn) []*Conn {
+	s = s[:0] //clearslice:ignore CS001 // want `//clearslice:ignore directive has no reason; add one after "--"`
+	return s
+}
+
+func unknownCheck(s []*Conn) []*Conn {
+	s = s[:0] //clearslice:ignore CS999 -- typo // want `unknown check CS999 in //clearslice:ignore directive` `slice s of type \*ignore.Conn is resized to zero length`
+	return s
+}
+
+func unknownVerb(s []*Conn) []*Conn {
+	s = s[:0] //clearslice:skip -- typo // want `unknown directive //clearslice:skip; use //clearslice:ignore or //clearslice:ignore-file` `slice s of type \*ignore.Conn is resized to zero length`
+	return s
+}
+
+func lateFile(s []*Conn) []*Conn {
+	//clearslice:ignore-file -- too late // want `//clearslice:ignore-file must come before the first declaration of the file`
+	s = s[:0] // want `slice s of type \*ignore.Conn is resized to zero length`
+	return s
+}
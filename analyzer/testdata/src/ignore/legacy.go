@@ -0,0 +1,16 @@
+//clearslice:ignore-file CS001 -- legacy code, cleaned up separately
+
+package ignore
+
+func legacy(s []*Conn) []*Conn {
+	s = s[:0]
+	return s
+}
+
+func legacyRange(s [][]*Conn) {
+	// Only the named check is ignored.
+	for i, conns := range s {
+		conns = conns[:0] // want `assignment to range variable has no effect on the ranged collection: conns is a copy of an element of s`
+		_ = i
+	}
+}
@@ -0,0 +1,15 @@
+package imports
+
+import (
+	"runtime"
+	"sort"
+
+	xslices "golang.org/x/exp/slices"
+)
+
+func grouped(s []*int) {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+	sort.Ints(nil)
+	_ = xslices.Delete[[]int]
+}
@@ -0,0 +1,12 @@
+package imports
+
+import (
+	"fmt"
+	"runtime" // last standard library import
+)
+
+func last(s []*int) {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+	fmt.Println()
+}
@@ -0,0 +1,7 @@
+// Package imports holds truncations whose fixes need an import of slices the file does not have yet.
+package imports
+
+func none(s []*int) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return s
+}
@@ -0,0 +1,9 @@
+package imports
+
+import "slices"
+
+func present(s []*int) {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = slices.Contains[[]int]
+	_ = s
+}
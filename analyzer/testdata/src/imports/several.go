@@ -0,0 +1,10 @@
+package imports
+
+import "fmt"
+import "runtime"
+
+func several(s []*int) {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+	fmt.Println()
+}
@@ -0,0 +1,9 @@
+package imports
+
+import "sort"
+
+func single(s []*int) {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	sort.Ints(nil)
+	_ = s
+}
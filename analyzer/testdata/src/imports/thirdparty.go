@@ -0,0 +1,11 @@
+package imports
+
+import (
+	xslices "golang.org/x/exp/slices"
+)
+
+func thirdparty(s []*int) {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	_ = xslices.Delete[[]int]
+	_ = s
+}
@@ -0,0 +1,7 @@
+package imports
+
+func twice(a, b []*int) ([]*int, []*int) {
+	a = a[:0] // want `slice a of type \*int is resized to zero length without clearing elements`
+	b = b[:0] // want `slice b of type \*int is resized to zero length without clearing elements`
+	return a, b
+}
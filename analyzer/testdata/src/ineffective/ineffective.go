@@ -0,0 +1,58 @@
+package ineffective
+
+type conn struct {
+	addr string
+}
+
+type pool struct {
+	idle []*conn
+	ids  []int
+}
+
+func (p *pool) drain() {
+	p.idle = p.idle[:0]
+	clear(p.idle) // want `clear\(p.idle\) after truncating p.idle to zero length has no effect; clear before the truncation, or clear the full capacity with clear\(p.idle\[:cap\(p.idle\)\]\)`
+}
+
+func (p *pool) drainIDs() {
+	// The clear is useless whatever the element type
+	p.ids = p.ids[:0]
+	clear(p.ids) // want `clear\(p.ids\) after truncating p.ids to zero length has no effect`
+}
+
+func (p *pool) drainCleared() {
+	// Safe: the clear runs first
+	clear(p.idle)
+	p.idle = p.idle[:0]
+}
+
+func (p *pool) drainCapacity() {
+	// Safe: the clear covers the capacity
+	p.idle = p.idle[:0]
+	clear(p.idle[:cap(p.idle)])
+}
+
+func (p *pool) wipe() {
+	// The reslice is empty, so nothing is cleared
+	clear(p.idle[:0]) // want `clear of the zero-length slice p.idle\[:0\] has no effect; clear before the truncation, or clear the full capacity with clear\(p.idle\[:cap\(p.idle\)\]\)`
+}
+
+func _(s []*conn, log func(string)) []*conn {
+	s = s[:0] // want `slice s of type \*ineffective.conn is resized to zero length without clearing elements`
+	log("reset")
+	clear(s) // want `clear\(s\) has no effect: s was truncated to zero length at line 41; clear before the truncation, or clear the full capacity with clear\(s\[:cap\(s\)\]\)`
+	return s
+}
+
+func _(s []*conn, more []*conn) []*conn {
+	// Not an ineffective clear: s is refilled before it
+	s = s[:0] // want `slice s of type \*ineffective.conn is resized to zero length without clearing elements`
+	s = append(s, more...)
+	clear(s)
+	return s
+}
+
+func _(m map[string]*conn) {
+	// Safe: maps are never reported
+	clear(m)
+}
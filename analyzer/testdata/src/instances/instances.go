@@ -0,0 +1,70 @@
+package instances
+
+type Row struct {
+	cells []string
+}
+
+type Batch[T any] struct {
+	items []T
+}
+
+type Joined[K comparable, V any] struct {
+	pairs []struct {
+		key   K
+		value V
+	}
+}
+
+type Scalar[T any] struct {
+	value T
+}
+
+type RowRef = *Row
+
+func _(b *Batch[*Row]) {
+	// Unsafe: field of an instantiated generic type with pointer elements
+	b.items = b.items[:0] // want `slice b.items of type \*instances.Row is resized to zero length without clearing elements`
+}
+
+func _(b *Batch[map[string]*Row]) {
+	// Unsafe: field of an instantiated generic type with map elements
+	b.items = b.items[:0] // want `slice b.items of type map\[string\]\*instances.Row is resized to zero length without clearing elements`
+}
+
+func _(b *Batch[Batch[*Row]]) {
+	// Unsafe: nested instantiation
+	b.items = b.items[:0] // want `slice b.items of type instances.Batch\[\*instances.Row\] is resized to zero length without clearing elements`
+}
+
+func _(s []Scalar[*Row]) {
+	// Unsafe: instantiated struct element whose field is a type argument holding references
+	s = s[:0] // want `slice s of type instances.Scalar\[\*instances.Row\] is resized to zero length without clearing elements`
+	_ = s
+}
+
+func _(j *Joined[int, *Row]) {
+	// Unsafe: anonymous struct element with a field of type argument type
+	j.pairs = j.pairs[:0] // want `slice j.pairs of type struct{key int; value \*instances.Row} is resized to zero length without clearing elements`
+}
+
+func _(b *Batch[int]) {
+	// Safe: instantiated with a primitive type
+	b.items = b.items[:0]
+}
+
+func _(s []Scalar[float64]) {
+	// Safe: instantiated struct element without references
+	s = s[:0]
+	_ = s
+}
+
+func _(j *Joined[int, bool]) {
+	// Safe: anonymous struct element with primitive type arguments
+	j.pairs = j.pairs[:0]
+}
+
+func _(s []RowRef) {
+	// Unsafe: alias of a pointer type
+	s = s[:0] // want `slice s of type instances.RowRef is resized to zero length without clearing elements`
+	_ = s
+}
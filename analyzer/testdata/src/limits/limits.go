@@ -0,0 +1,7 @@
+package limits
+
+// Empty is an imported constant length of zero.
+const Empty = 0
+
+// Batch is an imported nonzero constant length.
+const Batch = 8
@@ -0,0 +1,39 @@
+package lowbound
+
+import "runtime"
+
+func _() {
+	// Unsafe: explicit zero low bound
+	s := []*int{new(int)}
+	s = s[0:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: explicit zero low bound with a maximum capacity
+	s := []*int{new(int)}
+	s = s[0:0:cap(s)] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Safe: clear() directly preceding the explicit zero low bound form
+	s := []*int{new(int)}
+	clear(s)
+	s = s[0:0]
+	runtime.KeepAlive(s)
+}
+
+func _(i int) {
+	// Safe: a variable low bound is left alone even if it is zero at run time
+	s := []*int{new(int)}
+	s = s[i:0]
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Safe: explicit zero low bound on a primitive slice
+	s := []int{1}
+	s = s[0:0]
+	runtime.KeepAlive(s)
+}
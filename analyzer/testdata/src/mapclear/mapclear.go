@@ -0,0 +1,57 @@
+package mapclear
+
+type cache struct {
+	entries map[string]*int
+	byID    map[int][]byte
+}
+
+func (c *cache) Reset() {
+	for k := range c.entries { // want `range loop deleting every key of map c.entries can be replaced with clear\(c.entries\)`
+		delete(c.entries, k)
+	}
+}
+
+func _(m map[int]bool) []int {
+	// k is declared outside the loop and may be used after it, so there is no fix
+	var k int
+	for k = range m { // want `range loop deleting every key of map m can be replaced with clear\(m\)`
+		delete(m, k)
+	}
+	return []int{k}
+}
+
+func (c *cache) drop() {
+	// The comment on the loop line stays at the end of it, the one in the body goes above
+	for id := range c.byID { /* drop every ID */ // want `range loop deleting every key of map c.byID can be replaced with clear\(c.byID\)`
+		// Including those still referenced
+		delete(c.byID, id)
+	}
+}
+
+func _(m map[int]bool, other map[int]bool) {
+	// Not every key of the ranged map: deletes from another map
+	for k := range m {
+		delete(other, k)
+	}
+}
+
+func _(m map[int]bool) {
+	// Not just a delete
+	for k := range m {
+		if k > 0 {
+			delete(m, k)
+		}
+	}
+}
+
+func _(c *cache, again bool) {
+	// The label stays on the replacement for the goto
+retry:
+	for k := range c.byID { // want `range loop deleting every key of map c.byID can be replaced with clear\(c.byID\)`
+		delete(c.byID, k)
+	}
+	if again {
+		again = false
+		goto retry
+	}
+}
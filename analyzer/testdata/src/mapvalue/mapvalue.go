@@ -0,0 +1,53 @@
+package mapvalue
+
+import "runtime"
+
+type Conn struct {
+	addr string
+}
+
+func _(m map[string][]*Conn, k string) {
+	// Unsafe: read-modify-write truncation of a map value, reported once and collapsed by the fix
+	v := m[k]
+	v = v[:0] // want `slice m\[k\] of type \*mapvalue.Conn is resized to zero length through v without clearing elements`
+	m[k] = v
+}
+
+func _(m map[string][]*Conn, k string) {
+	// Safe: the local copy shares the backing array, so clearing it clears the map value's elements
+	v := m[k]
+	clear(v)
+	v = v[:0]
+	m[k] = v
+}
+
+func _(m map[string][]*Conn, k string) {
+	// Unsafe: the local copy is used after the sequence, so the statements cannot be collapsed
+	v := m[k]
+	v = v[:0] // want `slice m\[k\] of type \*mapvalue.Conn is resized to zero length through v without clearing elements`
+	m[k] = v
+	runtime.KeepAlive(v)
+}
+
+func _(m map[string][]*Conn, k string) {
+	// Unsafe: the local copy existed before the sequence, so the statements cannot be collapsed
+	var v []*Conn
+	v = m[k]
+	v = v[:0] // want `slice m\[k\] of type \*mapvalue.Conn is resized to zero length through v without clearing elements`
+	m[k] = v
+}
+
+func _(m map[string][]int, k string) {
+	// Safe: map value elements do not contain references
+	v := m[k]
+	v = v[:0]
+	m[k] = v
+}
+
+func _(m map[string][]*Conn, k string) {
+	// Unsafe: interleaved statements are not recognized as the idiom, so the local truncation is reported on its own
+	v := m[k]
+	runtime.KeepAlive(k)
+	v = v[:0] // want `slice v of type \*mapvalue.Conn is resized to zero length without clearing elements`
+	m[k] = v
+}
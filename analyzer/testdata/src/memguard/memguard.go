@@ -0,0 +1,8 @@
+// Package memguard is a stub of an organization's slice helpers for the tests of -fix-template.
+package memguard
+
+// ResetSlice clears the elements of *s and truncates it to zero length.
+func ResetSlice[T any](s *[]T) {
+	clear(*s)
+	*s = (*s)[:0]
+}
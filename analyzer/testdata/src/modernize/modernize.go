@@ -0,0 +1,71 @@
+package modernize
+
+type Conn struct{ id int }
+
+type point struct{ x, y int }
+
+type Pool struct {
+	conns []*Conn
+}
+
+func loops(p *Pool, ids []int, names []string, points []point, flags []bool, anys []any) {
+	for i := range p.conns { // want `range loop zeroing every element of slice p.conns can be replaced with clear\(p.conns\)`
+		p.conns[i] = nil
+	}
+	for i := range ids { // want `range loop zeroing every element of slice ids can be replaced with clear\(ids\)`
+		ids[i] = 0
+	}
+	for i := range names { // want `slice names`
+		names[i] = ""
+	}
+	for i := range points { // want `slice points`
+		points[i] = point{}
+	}
+	for i := range flags { // want `slice flags`
+		flags[i] = false
+	}
+	for i := range anys { // want `slice anys`
+		anys[i] = nil
+	}
+}
+
+func notZeroing(ids []int, anys []any, m map[int]*Conn, arr [4]int) {
+	for i := range ids {
+		ids[i] = 1
+	}
+	for i := range ids {
+		ids[i] = 0
+		println(i)
+	}
+	for i := range anys {
+		anys[i] = 0
+	}
+	for i := range anys {
+		anys[i] = struct{}{}
+	}
+	for k := range m {
+		m[k] = nil
+	}
+	for i := range arr {
+		arr[i] = 0
+	}
+	for i, v := range ids {
+		ids[i] = 0
+		_ = v
+	}
+}
+
+func unfixable(ids []int) int {
+	var i int
+	for i = range ids { // want `slice ids`
+		ids[i] = 0
+	}
+	return i
+}
+
+var zeroConns = make([]*Conn, 64)
+
+func zeroCopies(p *Pool, conns []*Conn) {
+	copy(p.conns, zeroConns) // want `copy from zero buffer zeroConns into p.conns can be replaced with clear\(p.conns\)`
+	copy(conns, zeroConns)   // want `copy from zero buffer zeroConns into conns can be replaced with clear\(conns\)`
+}
@@ -0,0 +1,55 @@
+package move
+
+type Event struct{ name string }
+
+type sink struct {
+	events  []*Event
+	pending []*Event
+}
+
+func flush(s *sink, batch []*Event) []*Event {
+	// Safe: the events now live in s.events
+	s.events = append(s.events, batch...)
+	batch = batch[:0]
+	return batch
+}
+
+func flushLocal(batch []*Event) ([]*Event, []*Event) {
+	var dst []*Event
+	// Safe: statements in between do not mention batch
+	dst = append(dst, batch...)
+	println(len(dst))
+	batch = batch[:0]
+	return dst, batch
+}
+
+func partial(s *sink, batch []*Event) []*Event {
+	s.events = append(s.events, batch[1:]...)
+	batch = batch[:0] // want `slice batch of type \*move.Event is resized to zero length without clearing elements`
+	return batch
+}
+
+func usedInBetween(s *sink, batch []*Event) []*Event {
+	s.events = append(s.events, batch...)
+	batch[0] = &Event{}
+	batch = batch[:0] // want `slice batch of type \*move.Event is resized to zero length without clearing elements`
+	return batch
+}
+
+func field(s *sink) {
+	s.events = append(s.events, s.pending...)
+	s.pending = s.pending[:0] // want `slice s.pending of type \*move.Event is resized to zero length without clearing elements`
+}
+
+func retained(s *sink, batch []*Event) []*Event {
+	s.pending = batch
+	s.events = append(s.events, batch...)
+	batch = batch[:0] // want `slice batch of type \*move.Event is resized to zero length without clearing elements`
+	return batch
+}
+
+func notAppended(s *sink, batch []*Event) []*Event {
+	s.events = append(s.events, nil)
+	batch = batch[:0] // want `slice batch of type \*move.Event is resized to zero length without clearing elements`
+	return batch
+}
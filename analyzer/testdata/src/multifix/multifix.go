@@ -0,0 +1,36 @@
+package multifix
+
+type Conn struct{}
+
+type pool struct {
+	conns []*Conn
+	idle  []*Conn
+	byKey map[string][]*Conn
+}
+
+func (p *pool) reset() {
+	p.conns = p.conns[:0] // want `slice p.conns of type \*multifix.Conn is resized to zero length without clearing elements`
+	p.idle = p.idle[:0]   // want `slice p.idle of type \*multifix.Conn is resized to zero length without clearing elements`
+}
+
+func truncate(slices []*Conn, conns []*Conn) ([]*Conn, []*Conn) {
+	// The parameter takes the name slices, so every fix of the file imports the package under another name.
+	slices = slices[:0] // want `slice slices of type \*multifix.Conn is resized to zero length without clearing elements`
+	conns = conns[:0]   // want `slice conns of type \*multifix.Conn is resized to zero length without clearing elements`
+	return slices, conns
+}
+
+func (p *pool) remove(i int) {
+	copy(p.conns[i:], p.conns[i+1:])
+	p.conns = p.conns[:len(p.conns)-1] // want `slice p.conns of type \*multifix.Conn has elements shifted out with copy`
+}
+
+func (p *pool) splice(i, j int) {
+	p.idle = append(p.idle[:i], p.idle[j:]...) // want `slice p.idle of type \*multifix.Conn has elements removed with append`
+}
+
+func (p *pool) resetKey(k string) {
+	v := p.byKey[k]
+	v = v[:0] // want `slice p.byKey\[k\] of type \*multifix.Conn is resized to zero length through v without clearing elements`
+	p.byKey[k] = v
+}
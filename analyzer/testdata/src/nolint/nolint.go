@@ -0,0 +1,52 @@
+package nolint
+
+func sameLine(s []*int) []*int {
+	s = s[:0] //nolint:clearslice // the elements are owned by the caller
+	return s
+}
+
+func bare(s []*int) []*int {
+	s = s[:0] //nolint
+	return s
+}
+
+func lineAbove(s []*int) []*int {
+	//nolint:gocritic,clearslice
+	s = s[:0]
+	return s
+}
+
+func all(s []*int) []*int {
+	s = s[:0] //nolint:all
+	return s
+}
+
+//nolint:clearslice
+func wholeFunc(s, t []*int) ([]*int, []*int) {
+	s = s[:0]
+	t = t[:0]
+	return s, t
+}
+
+func otherLinter(s []*int) []*int {
+	s = s[:0] //nolint:gocritic // want `slice s of type \*int is resized to zero length without clearing elements`
+	return s
+}
+
+func spaced(s []*int) []*int {
+	// A space before nolint makes it an ordinary comment, as in golangci-lint.
+	s = s[:0] // nolint:clearslice // want `slice s of type \*int is resized to zero length without clearing elements`
+	return s
+}
+
+func misaligned(s []*int) []*int {
+	//nolint:clearslice
+	println()
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return s
+}
+
+func prefix(s []*int) []*int {
+	s = s[:0] //nolinter // want `slice s of type \*int is resized to zero length without clearing elements`
+	return s
+}
@@ -0,0 +1,13 @@
+package nolintmap
+
+func suppressed(m map[int]*int) {
+	for k := range m { //nolint:mapclear
+		delete(m, k)
+	}
+}
+
+func otherLinter(m map[int]*int) {
+	for k := range m { //nolint:clearslice // want `range loop deleting every key of map m can be replaced with clear\(m\)`
+		delete(m, k)
+	}
+}
@@ -0,0 +1,53 @@
+package paramcopy
+
+type Owner struct{ jobs []*Job }
+
+func (o *Owner) Jobs() []*Job { return o.jobs }
+
+func (o *Owner) IDs() []int { return nil }
+
+func jobsOf(o *Owner) []*Job { return o.jobs }
+
+func resetJobs(o *Owner) {
+	buf := o.Jobs()
+	buf = buf[:0] // want `truncation of buf only changes the local slice header: buf holds the result of o.Jobs\(\) and its new value is never used, so the slice it returned keeps its length and elements`
+}
+
+func resetIDsOf(o *Owner) {
+	// Reported whatever the element type
+	ids := o.IDs()
+	println(len(ids))
+	ids = ids[:0] // want `truncation of ids only changes the local slice header: ids holds the result of o.IDs\(\)`
+}
+
+func resetFunc(o *Owner) {
+	var buf []*Job
+	buf = jobsOf(o)
+	buf = buf[:0] // want `truncation of buf only changes the local slice header: buf holds the result of jobsOf\(o\)`
+}
+
+func refill(o *Owner) {
+	// Unsafe but effective: the new value is stored back
+	buf := o.Jobs()
+	buf = buf[:0] // want `slice buf of type \*paramcopy.Job is resized to zero length without clearing elements`
+	o.jobs = append(buf, &Job{})
+}
+
+func refillReturned(o *Owner) []*Job {
+	buf := o.Jobs()
+	buf = buf[:0] // want `slice buf of type \*paramcopy.Job is resized to zero length without clearing elements`
+	return buf
+}
+
+func reassigned(o *Owner, other []*Job) {
+	// The truncated value no longer comes from the call, and the dead store is not reported either
+	buf := o.Jobs()
+	buf = other
+	buf = buf[:0]
+}
+
+func fresh() {
+	// A fresh local slice that is never used again becomes unreachable as a whole
+	buf := make([]*Job, 8)
+	buf = buf[:0]
+}
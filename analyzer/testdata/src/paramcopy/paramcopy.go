@@ -0,0 +1,51 @@
+package paramcopy
+
+type Job struct {
+	id int
+}
+
+func drain(items []*Job) {
+	for _, it := range items {
+		_ = it
+	}
+	items = items[:0] // want `truncation of parameter copy does not affect the caller: items is a by-value parameter of drain and its new value is never used`
+}
+
+func resetIDs(ids []int) {
+	// Reported whatever the element type
+	ids = ids[:0] // want `truncation of parameter copy does not affect the caller: ids is a by-value parameter of resetIDs and its new value is never used`
+}
+
+func drainReturned(items []*Job) []*Job {
+	// Unsafe but effective: the new value is returned
+	items = items[:0] // want `slice items of type \*paramcopy.Job is resized to zero length without clearing elements`
+	return items
+}
+
+func drainStored(items []*Job, dst *[]*Job) {
+	// Unsafe but effective: the new value is stored
+	items = items[:0] // want `slice items of type \*paramcopy.Job is resized to zero length without clearing elements`
+	*dst = items
+}
+
+func drainInLoop(items []*Job, n int) {
+	// Unsafe but effective: the next iteration sees the new value
+	for i := 0; i < n; i++ {
+		items = append(items, &Job{id: i})
+		items = items[:0] // want `slice items of type \*paramcopy.Job is resized to zero length without clearing elements`
+	}
+}
+
+func drainCaptured(items []*Job) func() int {
+	// Unsafe but effective: the closure sees the new value
+	f := func() int { return len(items) }
+	items = items[:0] // want `slice items of type \*paramcopy.Job is resized to zero length without clearing elements`
+	return f
+}
+
+func drainAddressed(items []*Job) {
+	// Unsafe but effective: the new value may be read through the pointer
+	p := &items
+	items = items[:0] // want `slice items of type \*paramcopy.Job is resized to zero length without clearing elements`
+	_ = p
+}
@@ -0,0 +1,36 @@
+package paramcopy
+
+type Buffer struct {
+	items []*Job
+	ids   []int
+	inner struct{ items []*Job }
+	owner *Buffer
+}
+
+func (b Buffer) Reset() {
+	b.items = b.items[:0] // want `truncation of receiver copy does not affect the caller: b is a value receiver of Reset, so b.items only changes its copy of Buffer; use a pointer receiver`
+}
+
+func (b Buffer) ResetIDs() {
+	// Reported whatever the element type
+	b.ids = b.ids[:0] // want `truncation of receiver copy does not affect the caller: b is a value receiver of ResetIDs, so b.ids only changes its copy of Buffer`
+}
+
+func (b Buffer) ResetInner() {
+	b.inner.items = b.inner.items[:0] // want `truncation of receiver copy does not affect the caller: b is a value receiver of ResetInner, so b.inner.items only changes`
+}
+
+func (b Buffer) ResetOwner() {
+	// Effective: the owner is reached through a pointer
+	b.owner.items = b.owner.items[:0] // want `slice b.owner.items of type \*paramcopy.Job is resized to zero length without clearing elements`
+}
+
+func (b Buffer) Emptied() Buffer {
+	// Unsafe but effective: the modified copy is returned
+	b.items = b.items[:0] // want `slice b.items of type \*paramcopy.Job is resized to zero length without clearing elements`
+	return b
+}
+
+func (b *Buffer) ResetPointer() {
+	b.items = b.items[:0] // want `slice b.items of type \*paramcopy.Job is resized to zero length without clearing elements`
+}
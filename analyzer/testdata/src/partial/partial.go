@@ -0,0 +1,56 @@
+package partial
+
+type job struct {
+	payload []byte
+}
+
+type batcher struct {
+	batch []*job
+	ids   []int
+}
+
+func (b *batcher) keepFirst(keep int) {
+	// Unsafe: b.batch[keep:] stays in the backing array
+	b.batch = b.batch[:keep] // want `slice b.batch of type \*partial.job is resized to length keep without clearing elements; b.batch\[keep:\] remains reachable through the backing array`
+}
+
+func (b *batcher) dropLast() {
+	// Unsafe: the last element stays in the backing array
+	b.batch = b.batch[0 : len(b.batch)-1] // want `slice b.batch of type \*partial.job is resized to length len\(b.batch\)-1 without clearing elements; b.batch\[len\(b.batch\)-1:\] remains reachable through the backing array`
+}
+
+func (b *batcher) Reset(keep int) {
+	// Unsafe: partial truncations in reuse methods are reuse points too
+	b.batch = b.batch[:keep:keep] // want `slice b.batch of type \*partial.job is resized to length keep without clearing elements; b.batch\[keep:\] remains reachable through the backing array \(this method is a reuse point; retained elements survive until the next fill\)`
+}
+
+func _(s []*job) []*job {
+	// Unsafe: zero-length truncations keep their own message and fix
+	s = s[:0] // want `slice s of type \*partial.job is resized to zero length without clearing elements`
+	return s
+}
+
+func _(s []*job) []*job {
+	// Safe: keeps every element
+	s = s[:len(s)]
+	return s
+}
+
+func _(s []*job, n int) []*job {
+	// Safe: cleared before truncation
+	clear(s[n:])
+	clear(s)
+	s = s[:n]
+	return s
+}
+
+func (b *batcher) trimIDs(n int) {
+	// Safe: elements hold no references
+	b.ids = b.ids[:n]
+}
+
+func _(s []*job, n int) []*job {
+	// Not a truncation: advancing the start is a different pattern
+	s = s[1:n]
+	return s
+}
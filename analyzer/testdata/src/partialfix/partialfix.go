@@ -0,0 +1,37 @@
+package partialfix
+
+type job struct {
+	payload []byte
+}
+
+type batcher struct {
+	batch  []*job
+	queues map[string][]*job
+}
+
+func (b *batcher) keepFirst(keep int) {
+	b.batch = b.batch[:keep] // want `slice b.batch of type \*partialfix.job is resized to length keep without clearing elements`
+}
+
+func (b *batcher) keepThree() {
+	b.batch = b.batch[:3] // want `slice b.batch of type \*partialfix.job is resized to length 3 without clearing elements`
+}
+
+func (b *batcher) dropLast() {
+	b.batch = b.batch[0 : len(b.batch)-2] // want `slice b.batch of type \*partialfix.job is resized to length len\(b.batch\)-2 without clearing elements`
+}
+
+func (b *batcher) keepCounted(count func() int) {
+	// The call is spelled once in the fix, so it runs once.
+	b.batch = b.batch[:count()] // want `slice b.batch of type \*partialfix.job is resized to length count\(\) without clearing elements`
+}
+
+func (b *batcher) keepCapped(keep int) {
+	// The capacity of a full slice expression would be lost.
+	b.batch = b.batch[:keep:keep] // want `slice b.batch of type \*partialfix.job is resized to length keep without clearing elements`
+}
+
+func (b *batcher) keepQueue(name func() string, keep int) {
+	// The key is repeated by the fix, so it must be evaluated only once.
+	b.queues[name()] = b.queues[name()][:keep] // want `slice b.queues\[name\(\)\] of type \*partialfix.job is resized to length keep without clearing elements`
+}
@@ -0,0 +1,109 @@
+package pool
+
+import (
+	"slices"
+	"sync"
+)
+
+type Request struct {
+	headers map[string]string
+}
+
+type batch struct {
+	reqs []*Request
+	ids  []int
+}
+
+var (
+	bufPool   sync.Pool
+	batchPool sync.Pool
+)
+
+func release(buf []*Request) {
+	buf = buf[:0]    // want `slice buf of type \*pool.Request is resized to zero length without clearing elements`
+	bufPool.Put(buf) // want `slice buf of type \*pool.Request is put into a sync.Pool without clearing elements; the pooled backing array keeps them reachable until it is reused`
+}
+
+func releasePtr(buf []*Request) {
+	bufPool.Put(&buf) // want `slice buf of type \*pool.Request is put into a sync.Pool without clearing elements`
+}
+
+func releaseBatch(b *batch) {
+	// Only the field holding references is reported
+	defer batchPool.Put(b) // want `slice b.reqs of type \*pool.Request is put into a sync.Pool without clearing elements`
+}
+
+func releaseBatchNow(b *batch) {
+	// The fix clears the field of the wrapper
+	batchPool.Put(b) // want `slice b.reqs of type \*pool.Request is put into a sync.Pool without clearing elements`
+}
+
+func releaseThrough(p *[]*Request) {
+	bufPool.Put(*p) // want `slice \(\*p\) of type \*pool.Request is put into a sync.Pool without clearing elements`
+}
+
+func releasePooled() {
+	// The fix clears the slice pointed to
+	bp := bufPool.Get().(*[]*Request)
+	bufPool.Put(bp) // want `slice \(\*bp\) of type \*pool.Request is put into a sync.Pool without clearing elements`
+}
+
+func releasePooledCleared(bp *[]*Request) {
+	// Safe: the slice pointed to is cleared up to its capacity
+	clear((*bp)[:cap(*bp)])
+	bufPool.Put(bp)
+}
+
+func releaseAt(bufs [][]*Request, i int) {
+	// No fix: the index would be evaluated a second time
+	bufPool.Put(bufs[i]) // want `slice bufs\[i\] of type \*pool.Request is put into a sync.Pool without clearing elements`
+}
+
+func releaseNext(next func() []*Request) {
+	// No fix: the call would run a second time
+	bufPool.Put(next()) // want `slice next\(\) of type \*pool.Request is put into a sync.Pool without clearing elements`
+}
+
+func releaseCleared(buf []*Request) {
+	// Safe: cleared earlier in the function
+	clear(buf)
+	bufPool.Put(buf[:0])
+}
+
+func releaseDeleted(buf []*Request) {
+	// Safe: cleared with a full-range slices.Delete
+	buf = slices.Delete(buf, 0, len(buf))
+	bufPool.Put(buf)
+}
+
+func releaseBatchZeroed(b *batch) {
+	// Safe: the field is zeroed by a loop
+	for i := range b.reqs {
+		b.reqs[i] = nil
+	}
+	batchPool.Put(b)
+}
+
+func releaseZeroedFull(buf []*Request) {
+	// Safe: zeroed by a loop up to the capacity
+	for i := range buf[:cap(buf)] {
+		buf[:cap(buf)][i] = nil
+	}
+	bufPool.Put(buf)
+}
+
+func releaseBatchCleared(b *batch) {
+	// Safe: the field is cleared up to its capacity
+	b.reqs = b.reqs[:0]
+	clear(b.reqs[:cap(b.reqs)])
+	batchPool.Put(b)
+}
+
+type otherPool struct{}
+
+func (otherPool) Put(any) {}
+
+func releaseOther(buf []*Request, p otherPool) {
+	// Safe: not a sync.Pool
+	p.Put(buf)
+}
@@ -0,0 +1,29 @@
+package popback
+
+import (
+	"strings"
+
+	"popback/vm"
+)
+
+type label string
+
+type box[T any] struct {
+	v T
+}
+
+type machine struct {
+	frames   []vm.Frame
+	pairs    []vm.Pair[string, *frame]
+	builders []strings.Builder
+	labels   []label
+	boxes    []box[*frame]
+}
+
+func popRecord() {
+	// Unsafe: the record type is not exported, so the zero value cannot be spelled here
+	recs := vm.Records()
+	r := recs[len(recs)-1]
+	recs = recs[:len(recs)-1] // want `slice recs of type popback/vm.record pops its last element into r without clearing the popped slot; set recs\[len\(recs\)-1\] to its zero value before shrinking`
+	_, _ = r, recs
+}
@@ -0,0 +1,42 @@
+package popback
+
+import (
+	"fmt"
+)
+
+// The pops below zero the slots with types of packages this file does not import, so their fixes add the imports.
+
+func (m *machine) popFrame() {
+	// Unsafe: the frame of another package, qualified by its package name
+	f := m.frames[len(m.frames)-1]
+	m.frames = m.frames[:len(m.frames)-1] // want `slice m.frames of type popback/vm.Frame pops its last element into f without clearing the popped slot; set m.frames\[len\(m.frames\)-1\] = vm.Frame\{\} before shrinking`
+	_ = f
+}
+
+func (m *machine) popPair() {
+	// Unsafe: a generic instantiation, with a type argument of this package
+	p := m.pairs[len(m.pairs)-1]
+	m.pairs = m.pairs[:len(m.pairs)-1] // want `slice m.pairs of type popback/vm.Pair\[string, \*popback.frame\] pops its last element into p without clearing the popped slot; set m.pairs\[len\(m.pairs\)-1\] = vm.Pair\[string, \*frame\]\{\} before shrinking`
+	_ = p
+}
+
+func (m *machine) popBuilder() {
+	// Unsafe: a standard library struct
+	b := m.builders[len(m.builders)-1]
+	m.builders = m.builders[:len(m.builders)-1] // want `slice m.builders of type strings.Builder pops its last element into b without clearing the popped slot; set m.builders\[len\(m.builders\)-1\] = strings.Builder\{\} before shrinking`
+	fmt.Println(b.String())
+}
+
+func (m *machine) popLabel() {
+	// Unsafe: a named string type, zeroed with an empty string
+	l := m.labels[len(m.labels)-1]
+	m.labels = m.labels[:len(m.labels)-1] // want `slice m.labels of type popback.label pops its last element into l without clearing the popped slot; set m.labels\[len\(m.labels\)-1\] = "" before shrinking`
+	_ = l
+}
+
+func (m *machine) popBox() {
+	// Unsafe: an unexported generic type of this package, unqualified
+	b := m.boxes[len(m.boxes)-1]
+	m.boxes = m.boxes[:len(m.boxes)-1] // want `slice m.boxes of type popback.box\[\*popback.frame\] pops its last element into b without clearing the popped slot; set m.boxes\[len\(m.boxes\)-1\] = box\[\*frame\]\{\} before shrinking`
+	_ = b
+}
@@ -0,0 +1,67 @@
+package popback
+
+type frame struct {
+	locals map[string]any
+}
+
+type state struct {
+	open *frame
+}
+
+type interp struct {
+	frames []*frame
+	states []state
+	depths []int
+}
+
+func (in *interp) pop() *frame {
+	// Unsafe: the popped slot still references the frame
+	f := in.frames[len(in.frames)-1]
+	in.frames = in.frames[:len(in.frames)-1] // want `slice in.frames of type \*popback.frame pops its last element into f without clearing the popped slot; set in.frames\[len\(in.frames\)-1\] = nil before shrinking`
+	return f
+}
+
+func (in *interp) popState() state {
+	// Unsafe: hoisted index, struct elements
+	n := len(in.states) - 1
+	st := in.states[n]
+	in.states = in.states[:n] // want `slice in.states of type popback.state pops its last element into st without clearing the popped slot; set in.states\[n\] = state\{\} before shrinking`
+	return st
+}
+
+func (in *interp) popBoth() (f *frame) {
+	// Unsafe: read and truncation in one statement, no place for the zeroing
+	f, in.frames = in.frames[len(in.frames)-1], in.frames[:len(in.frames)-1] // want `slice in.frames of type \*popback.frame pops its last element into f without clearing the popped slot; set in.frames\[len\(in.frames\)-1\] = nil before shrinking`
+	return f
+}
+
+func (in *interp) popReversed() (f *frame) {
+	// Unsafe: the truncation written first in the tuple
+	n := len(in.frames) - 1
+	in.frames, f = in.frames[:n], in.frames[n] // want `slice in.frames of type \*popback.frame pops its last element into f without clearing the popped slot; set in.frames\[n\] = nil before shrinking`
+	return f
+}
+
+func (in *interp) popCleared() *frame {
+	// Safe: the popped slot is zeroed before shrinking
+	n := len(in.frames) - 1
+	f := in.frames[n]
+	in.frames[n] = nil
+	in.frames = in.frames[:n]
+	return f
+}
+
+func (in *interp) popStateCleared() state {
+	// Safe: zeroed with an empty composite literal
+	st := in.states[len(in.states)-1]
+	in.states[len(in.states)-1] = state{}
+	in.states = in.states[:len(in.states)-1]
+	return st
+}
+
+func (in *interp) popDepth() int {
+	// Safe: elements hold no references
+	d := in.depths[len(in.depths)-1]
+	in.depths = in.depths[:len(in.depths)-1]
+	return d
+}
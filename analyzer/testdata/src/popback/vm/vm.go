@@ -0,0 +1,21 @@
+package vm
+
+// Frame is an activation record of the virtual machine.
+type Frame struct {
+	Locals map[string]any
+}
+
+// Pair associates a value with a key.
+type Pair[K comparable, V any] struct {
+	Key K
+	Val V
+}
+
+type record struct {
+	data []byte
+}
+
+// Records returns the records of the journal.
+func Records() []record {
+	return nil
+}
@@ -0,0 +1,37 @@
+// Package purity holds truncations of map values whose keys may or may not be repeated in a fix.
+package purity
+
+type Conn struct{}
+
+type config struct {
+	names struct{ primary string }
+}
+
+func key() string { return "k" }
+
+func _(cache map[string][]*Conn, cfg *config) {
+	// Unsafe: a key selecting nested fields can be repeated
+	cache[cfg.names.primary] = cache[cfg.names.primary][:0] // want `slice cache\[cfg.names.primary\] of type \*purity.Conn is resized to zero length without clearing elements`
+}
+
+func _(cache map[string][]*Conn, keys [2]string) {
+	// Unsafe: a key indexing an array by a constant can be repeated
+	cache[keys[0]] = cache[keys[0]][:0] // want `slice cache\[keys\[0\]\] of type \*purity.Conn is resized to zero length without clearing elements`
+}
+
+func _(cache map[string][]*Conn) {
+	// Unsafe: a key computed by a call gets no fix
+	cache[key()] = cache[key()][:0] // want `slice cache\[key\(\)\] of type \*purity.Conn is resized to zero length without clearing elements`
+}
+
+func _(cache map[string][]*Conn, keys []string, i int) {
+	// Unsafe: a key indexing a slice by a variable gets no fix
+	cache[keys[i]] = cache[keys[i]][:0] // want `slice cache\[keys\[i\]\] of type \*purity.Conn is resized to zero length without clearing elements`
+}
+
+func _(cache map[string][]*Conn) {
+	// Unsafe: the local copy of a value whose key is computed by a call is not collapsed
+	conns := cache[key()]
+	conns = conns[:0] // want `slice cache\[key\(\)\] of type \*purity.Conn is resized to zero length through conns without clearing elements`
+	cache[key()] = conns
+}
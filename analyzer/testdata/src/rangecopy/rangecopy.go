@@ -0,0 +1,73 @@
+package rangecopy
+
+type buffer struct {
+	data []byte
+}
+
+type router struct {
+	table  [][]*buffer
+	routes map[string][]*buffer
+	counts [][]int
+}
+
+func (r *router) resetAll() {
+	// The value variable goes away with its only use
+	for i, bufs := range r.table {
+		bufs = bufs[:0] // want `assignment to range variable has no effect on the ranged collection: bufs is a copy of an element of r.table`
+		_ = i
+	}
+}
+
+func (r *router) resetBlank() {
+	// The blank key becomes an index
+	for _, bufs := range r.table {
+		bufs = bufs[:0] // want `assignment to range variable has no effect on the ranged collection: bufs is a copy of an element of r.table`
+	}
+}
+
+func (r *router) resetCapacity() {
+	// The capacity cannot be dropped with slices.Delete, so the bounds stay as written
+	for _, bufs := range r.table {
+		bufs = bufs[:0:0] // want `assignment to range variable has no effect on the ranged collection: bufs is a copy of an element of r.table`
+	}
+}
+
+func (r *router) resetAndFill(buf *buffer) {
+	// The copy is written back, so it is checked as any other slice
+	for i, bufs := range r.table {
+		bufs = bufs[:0] // want `slice bufs of type \*rangecopy.buffer is resized to zero length without clearing elements`
+		r.table[i] = append(bufs, buf)
+	}
+}
+
+func (r *router) resetRoutes() {
+	// No fix: the elements of a map are not written back while ranging over it
+	for name, bufs := range r.routes {
+		bufs = bufs[:0:0] // want `assignment to range variable has no effect on the ranged collection: bufs is a copy of an element of r.routes`
+		_ = name
+	}
+}
+
+func (r *router) resetWithoutKey() {
+	// No fix: the value variable is used for something else
+	for _, bufs := range r.table {
+		_ = len(bufs)
+		bufs = bufs[:0] // want `assignment to range variable has no effect on the ranged collection: bufs is a copy of an element of r.table`
+	}
+}
+
+func (r *router) resetBounded() {
+	// No fix: the bounds refer to the copy
+	for i, bufs := range r.table {
+		bufs = bufs[len(bufs):] // want `assignment to range variable has no effect on the ranged collection: bufs is a copy of an element of r.table`
+		_ = i
+	}
+}
+
+func (r *router) resetCounts() {
+	// Safe: elements hold no references
+	for i, counts := range r.counts {
+		counts = counts[:0]
+		_ = i
+	}
+}
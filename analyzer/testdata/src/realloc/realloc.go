@@ -0,0 +1,52 @@
+package realloc
+
+type Row struct{ cells []string }
+
+type Batch struct {
+	rows []*Row
+	ids  []int
+}
+
+func fill(b *Batch, inputs [][]*Row) {
+	for _, in := range inputs {
+		b.rows = nil    // want `field b.rows is reset to nil inside a loop, which discards its backing array; keep the capacity with b.rows = slices.Delete\(b.rows, 0, len\(b.rows\)\)`
+		b.ids = []int{} // want `field b.ids is reset to an empty slice literal inside a loop, which discards its backing array; keep the capacity with b.ids = b.ids\[:0\]`
+		b.rows = append(b.rows, in...)
+	}
+}
+
+func (b *Batch) Reset() {
+	b.rows = []*Row{} // want `field b.rows is reset to an empty slice literal inside reuse method Reset`
+	b.ids = nil       // want `inside reuse method Reset`
+}
+
+func NewBatch() *Batch {
+	b := &Batch{}
+	b.rows = nil
+	b.ids = []int{}
+	return b
+}
+
+func locals(inputs [][]int) {
+	for _, in := range inputs {
+		var ids []int
+		ids = nil
+		ids = append(ids, in...)
+		_ = ids
+	}
+}
+
+func closures(b *Batch, inputs [][]*Row) {
+	for range inputs {
+		func() {
+			b.rows = nil
+		}()
+	}
+}
+
+func nonEmpty(b *Batch) {
+	for {
+		b.ids = []int{1}
+		b.rows = make([]*Row, 0)
+	}
+}
@@ -0,0 +1,15 @@
+// Package frames declares an interface with the method, which the type implements.
+package frames
+
+// Frame values are used as resetters, which they would no longer be: no fix.
+type Frame struct {
+	locals []*int
+}
+
+type resetter interface{ Reset() }
+
+var _ resetter = Frame{}
+
+func (f Frame) Reset() {
+	f.locals = f.locals[:0] // want `truncation of receiver copy does not affect the caller: f is a value receiver of Reset, so f.locals only changes its copy of Frame; use a pointer receiver`
+}
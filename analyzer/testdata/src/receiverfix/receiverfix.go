@@ -0,0 +1,49 @@
+package receiverfix
+
+type Job struct{ name string }
+
+// Batch has pointer receivers elsewhere, so Reset can become one too.
+type Batch struct {
+	jobs []*Job
+}
+
+func (b Batch) Reset() {
+	b.jobs = b.jobs[:0] // want `truncation of receiver copy does not affect the caller: b is a value receiver of Reset, so b.jobs only changes its copy of Batch; use a pointer receiver`
+}
+
+func (b *Batch) Add(job *Job) {
+	b.jobs = append(b.jobs, job)
+}
+
+func use(b Batch, batches []Batch) {
+	b.Reset()
+	batches[0].Reset()
+}
+
+// Queue has another value-receiver method, so the method set would become inconsistent: no fix.
+type Queue struct {
+	jobs []*Job
+}
+
+func (q Queue) Reset() {
+	q.jobs = q.jobs[:0] // want `truncation of receiver copy does not affect the caller: q is a value receiver of Reset, so q.jobs only changes its copy of Queue; use a pointer receiver`
+}
+
+func (q Queue) Len() int {
+	return len(q.jobs)
+}
+
+// Stack is reset through a map element, which is not addressable: no fix.
+type Stack struct {
+	jobs []*Job
+}
+
+func (s Stack) Reset() {
+	s.jobs = s.jobs[:0] // want `truncation of receiver copy does not affect the caller: s is a value receiver of Reset, so s.jobs only changes its copy of Stack; use a pointer receiver`
+}
+
+func resetAll(stacks map[string]Stack) {
+	for name := range stacks {
+		stacks[name].Reset()
+	}
+}
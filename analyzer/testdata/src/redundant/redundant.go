@@ -0,0 +1,55 @@
+package redundant
+
+import "slices"
+
+type point struct{ x, y float64 }
+
+type stats struct {
+	counts []int
+}
+
+func deletes(ints []int, points []point, s *stats) ([]int, []point) {
+	ints = slices.Delete(ints, 0, len(ints))             // want `slices.Delete clears elements of slice ints of type int, which hold no references; truncate with ints\[:0\] instead`
+	points = slices.Delete(points, 0, len(points))       // want `slices.Delete clears elements of slice points of type redundant.point, which hold no references`
+	s.counts = slices.Delete(s.counts, 0, len(s.counts)) // want `slice s.counts of type int`
+	return ints, points
+}
+
+func clears(floats []float64, s *stats) []float64 {
+	clear(floats) // want `clearing slice floats of type float64 before truncating it is unnecessary, since its elements hold no references`
+	floats = floats[:0]
+
+	clear(s.counts[:cap(s.counts)]) // want `clearing slice s.counts of type int before truncating it is unnecessary`
+	s.counts = s.counts[:0]
+	return floats
+}
+
+func references(strs []string, ptrs []*int) ([]string, []*int) {
+	strs = slices.Delete(strs, 0, len(strs))
+	clear(ptrs)
+	ptrs = ptrs[:0]
+	return strs, ptrs
+}
+
+func partialDelete(ints []int, i int) []int {
+	clear(ints)
+	ints = ints[:i]
+	return slices.Delete(ints, 0, len(ints))
+}
+
+func generics[T any, N ~int | ~uint](ts []T, ns []N) ([]T, []N) {
+	ts = slices.Delete(ts, 0, len(ts))
+	ns = slices.Delete(ns, 0, len(ns)) // want `slice ns of type N`
+	return ts, ns
+}
+
+// A local function named like slices.Delete is not the standard one.
+type fake struct{}
+
+func (fake) Delete(s []int, i, j int) []int { return s[:i] }
+
+func notSlices(ints []int) []int {
+	var slices fake
+	ints = slices.Delete(ints, 0, len(ints))
+	return ints
+}
@@ -0,0 +1,50 @@
+package reextend
+
+type Conn struct{ id int }
+
+type Table struct {
+	conns []*Conn
+}
+
+func capacity(t *Table) []*Conn {
+	t.conns = t.conns[:0]         // want `slice t.conns of type \*reextend.Conn is resized to zero length without clearing elements`
+	return t.conns[:cap(t.conns)] // want `slice t.conns of type \*reextend.Conn is re-extended to length cap\(t.conns\) after being truncated without clearing elements at line 10; the stale elements become visible again`
+}
+
+func count(conns []*Conn, n int) []*Conn {
+	conns = conns[:0] // want `is resized to zero length without clearing elements`
+	if n > 0 {
+		conns = conns[:n] // want `re-extended to length n`
+	}
+	return conns
+}
+
+func appended(conns []*Conn, c *Conn) []*Conn {
+	conns = conns[:0] // want `is resized to zero length without clearing elements`
+	conns = append(conns, c)
+	return conns[:1]
+}
+
+func cleared(conns []*Conn) []*Conn {
+	clear(conns)
+	conns = conns[:0]
+	return conns[:cap(conns)]
+}
+
+func clearedCapacity(conns []*Conn) []*Conn {
+	conns = conns[:0]
+	clear(conns[:cap(conns)])
+	return conns[:cap(conns)]
+}
+
+func primitive(ids []int) []int {
+	ids = ids[:0]
+	return ids[:cap(ids)]
+}
+
+func earlier(conns []*Conn) []*Conn {
+	all := conns[:cap(conns)]
+	conns = conns[:0] // want `is resized to zero length without clearing elements`
+	_ = conns[:len(conns)]
+	return all
+}
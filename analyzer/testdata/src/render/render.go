@@ -0,0 +1,28 @@
+// Package render holds truncations whose targets must be spelled in fixes and messages exactly as written.
+package render
+
+import "slices"
+
+type state struct {
+	bufs []*int
+}
+
+type holder struct {
+	p     *[]*int
+	state state
+}
+
+func _(h *holder) {
+	// Unsafe: the parentheses of the dereferenced field are kept
+	*(h.p) = (*(h.p))[:0] // want `slice \*\(h.p\) of type \*int is resized to zero length without clearing elements`
+}
+
+func _(h *holder) {
+	// Unsafe: the parentheses inside the selector chain are kept
+	(h.state).bufs = (h.state).bufs[:0] // want `slice \(h.state\).bufs of type \*int is resized to zero length without clearing elements`
+}
+
+func _(h *holder) {
+	// Unsafe: the discarded result is assigned back to the target as written
+	slices.Delete((h.state).bufs, 0, 1) // want `result of slices.Delete is discarded; the shortened slice is lost and \(h.state\).bufs keeps its old length`
+}
@@ -0,0 +1,24 @@
+package resetmake
+
+type Item struct{ name string }
+
+type Recorder struct {
+	items []*Item
+	ids   []int
+	other []*Item
+}
+
+func (r *Recorder) Reset() {
+	r.items = make([]*Item, 0, cap(r.items)) // want `field r.items is reallocated with its own capacity in reuse method Reset, which discards its backing array; reuse it with r.items = slices.Delete\(r.items, 0, len\(r.items\)\)`
+	r.ids = make([]int, 0, cap(r.ids))       // want `reuse it with r.ids = r.ids\[:0\]`
+}
+
+func (r *Recorder) Clear() {
+	r.items = make([]*Item, 0, 2*cap(r.items))
+	r.other = make([]*Item, 0, cap(r.items))
+	r.ids = make([]int, len(r.ids), cap(r.ids))
+}
+
+func (r *Recorder) Flush() {
+	r.items = make([]*Item, 0, cap(r.items))
+}
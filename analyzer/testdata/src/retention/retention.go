@@ -0,0 +1,40 @@
+package retention
+
+import (
+	"bytes"
+	"io"
+)
+
+type header struct {
+	token []byte
+	name  []byte
+}
+
+var lastMagic []byte
+
+func parse(r io.Reader, h *header, index map[string][]byte) error {
+	line, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	h.token = line[10:14]     // want `subslice of line \(from io.ReadAll\) stored in h.token keeps its entire backing array reachable; store a copy with bytes.Clone`
+	lastMagic = line[:4]      // want `subslice of line \(from io.ReadAll\) stored in lastMagic keeps its entire backing array reachable`
+	index["tail"] = line[14:] // want `subslice of line \(from io.ReadAll\) stored in index\["tail"\] keeps its entire backing array reachable`
+	fields := bytes.Split(line, []byte{' '})
+	h.name = fields[1] // want `subslice of fields \(from bytes.Split\) stored in h.name keeps its entire backing array reachable`
+	local := line[4:8] // Safe: a local variable does not outlive the function
+	_ = local
+	return nil
+}
+
+func build() *header {
+	scratch := make([]byte, 0, 64<<10)
+	scratch = append(scratch, "token"...)
+	return &header{token: scratch[:5]} // want `subslice of scratch \(from make\) stored in field token of a header literal keeps its entire backing array reachable`
+}
+
+func small(h *header) {
+	// Safe: not a large slice
+	buf := make([]byte, 16)
+	h.token = buf[:4]
+}
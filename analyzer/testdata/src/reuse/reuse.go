@@ -0,0 +1,39 @@
+package reuse
+
+type Request struct {
+	body *[]byte
+}
+
+type Batch struct {
+	reqs []*Request
+	ids  []int
+}
+
+func (b *Batch) Reset() {
+	// Unsafe: truncation inside a Reset method is a reuse point
+	b.reqs = b.reqs[:0] // want `slice b.reqs of type \*reuse.Request is resized to zero length without clearing elements \(this method is a reuse point; retained elements survive until the next fill\)`
+	b.ids = b.ids[:0]
+}
+
+func (b *Batch) Clear() {
+	// Unsafe: closures inside a reuse method are part of it
+	func() {
+		b.reqs = b.reqs[:0] // want `\(this method is a reuse point; retained elements survive until the next fill\)`
+	}()
+}
+
+func (b *Batch) Recycle() { // want Recycle:`ClearsArgs\(params=\[\], fields=\[reqs\]\)`
+	// Safe: clear() directly preceding length adjustment
+	clear(b.reqs)
+	b.reqs = b.reqs[:0]
+}
+
+func (b *Batch) Drain() {
+	// Unsafe: not a reuse method, so the finding is an ordinary truncation
+	b.reqs = b.reqs[:0] // want `slice b.reqs of type \*reuse.Request is resized to zero length without clearing elements$`
+}
+
+func Reset(b *Batch) {
+	// Unsafe: only methods are reuse points, not functions with the same name
+	b.reqs = b.reqs[:0] // want `slice b.reqs of type \*reuse.Request is resized to zero length without clearing elements$`
+}
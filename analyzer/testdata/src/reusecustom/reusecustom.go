@@ -0,0 +1,19 @@
+package reusecustom
+
+type Request struct {
+	body *[]byte
+}
+
+type Batch struct {
+	reqs []*Request
+}
+
+func (b *Batch) Drain() {
+	// Unsafe: Drain is configured as a reuse method
+	b.reqs = b.reqs[:0] // want `slice b.reqs of type \*reusecustom.Request is resized to zero length without clearing elements \(this method is a reuse point; retained elements survive until the next fill\)`
+}
+
+func (b *Batch) Reset() {
+	// Unsafe: Reset is no longer in the configured list
+	b.reqs = b.reqs[:0] // want `slice b.reqs of type \*reusecustom.Request is resized to zero length without clearing elements$`
+}
@@ -0,0 +1,67 @@
+package ring
+
+type Event struct{ payload []byte }
+
+// Queue is a fixed-size ring buffer of events.
+type Queue struct {
+	buf        []*Event
+	head, tail int
+	size       int
+}
+
+func (q *Queue) Push(e *Event) {
+	q.buf[q.tail] = e
+	q.tail = (q.tail + 1) % len(q.buf)
+	q.size++
+}
+
+func (q *Queue) Pop() *Event {
+	e := q.buf[q.head]
+	q.head = (q.head + 1) % len(q.buf) // want `method Pop advances q.head past the slot q.buf\[q.head\] it read without clearing it; the element of type \*ring.Event stays reachable until the buffer wraps around; set q.buf\[q.head\] = nil before advancing`
+	q.size--
+	return e
+}
+
+func (q *Queue) PopIncrement() *Event {
+	e := q.buf[q.head]
+	q.head++ // want `method PopIncrement advances q.head past the slot q.buf\[q.head\]`
+	if q.head == len(q.buf) {
+		q.head = 0
+	}
+	return e
+}
+
+func (q *Queue) PopCleared() *Event {
+	e := q.buf[q.head]
+	q.buf[q.head] = nil
+	q.head = (q.head + 1) % len(q.buf)
+	return e
+}
+
+func (q *Queue) PopClearedOld() *Event {
+	old := q.head
+	q.head = (q.head + 1) % len(q.buf)
+	e := q.buf[old]
+	q.buf[old] = nil
+	return e
+}
+
+func (q *Queue) Peek() *Event {
+	return q.buf[q.head]
+}
+
+func (q *Queue) Skip() {
+	q.head = (q.head + 1) % len(q.buf)
+}
+
+// Ints holds no references, so its consumed slots are harmless.
+type Ints struct {
+	buf  []int
+	head int
+}
+
+func (r *Ints) Pop() int {
+	v := r.buf[r.head]
+	r.head = (r.head + 1) % len(r.buf)
+	return v
+}
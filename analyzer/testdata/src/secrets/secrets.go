@@ -0,0 +1,32 @@
+package secrets
+
+type Session struct {
+	sessionKey []byte
+	buf        []byte
+	pin        []rune
+}
+
+func reset(s *Session, keyBuf, data []byte, password []rune) {
+	keyBuf = keyBuf[:0]             // want `slice keyBuf of type byte may hold secret data and is resized to zero length without wiping it; clear\(keyBuf\) \(or zero it in a loop\) before shrinking`
+	s.sessionKey = s.sessionKey[:0] // want `slice s.sessionKey of type byte may hold secret data`
+	password = password[:0]         // want `slice password of type rune may hold secret data`
+	data = data[:0]
+	s.buf = s.buf[:0]
+	s.pin = s.pin[:0]
+	_, _, _ = keyBuf, password, data
+}
+
+func wiped(s *Session, tokenBytes []byte) []byte {
+	clear(tokenBytes)
+	tokenBytes = tokenBytes[:0]
+	for i := range s.sessionKey {
+		s.sessionKey[i] = 0
+	}
+	s.sessionKey = s.sessionKey[:0]
+	return tokenBytes
+}
+
+func tuple(nonce, secret []byte) ([]byte, []byte) {
+	nonce, secret = nonce[:0], secret[:0] // want `slice nonce of type byte` `slice secret of type byte`
+	return nonce, secret
+}
@@ -0,0 +1,7 @@
+package secretscustom
+
+func reset(keyBuf, masterSeed []byte) ([]byte, []byte) {
+	keyBuf = keyBuf[:0]
+	masterSeed = masterSeed[:0] // want `slice masterSeed of type byte may hold secret data`
+	return keyBuf, masterSeed
+}
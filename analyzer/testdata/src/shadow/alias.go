@@ -0,0 +1,9 @@
+// Package shadow holds fixes in files that import slices under another name or declare identifiers named slices.
+package shadow
+
+import sl "slices"
+
+func alias(s []*int) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return sl.Clip(s)
+}
@@ -0,0 +1,10 @@
+package shadow
+
+import (
+	"golang.org/x/exp/slices"
+)
+
+func exp(s []*int) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return slices.Delete(s, 0, 0)
+}
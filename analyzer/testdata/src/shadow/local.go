@@ -0,0 +1,19 @@
+package shadow
+
+import (
+	"fmt"
+	"slices"
+)
+
+func local(s []*int) {
+	fmt.Println(slices.Contains(s, nil))
+	for _, slices := range [][]*int{s} {
+		s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+		fmt.Println(len(slices))
+	}
+}
+
+func unshadowed(s []*int) []*int {
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	return s
+}
@@ -0,0 +1,13 @@
+package shadow
+
+import "runtime"
+
+type sorter struct{}
+
+func (sorter) sort() {}
+
+func param(s []*int, slices sorter) {
+	slices.sort()
+	s = s[:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
@@ -0,0 +1,59 @@
+package shift
+
+type entry struct {
+	value any
+}
+
+type history struct {
+	entries []*entry
+	sizes   []int
+}
+
+func (h *history) remove(i int) {
+	// Unsafe: the last slot keeps a stale duplicate
+	copy(h.entries[i:], h.entries[i+1:])
+	h.entries = h.entries[:len(h.entries)-1] // want `slice h.entries of type \*shift.entry has elements shifted out with copy without clearing the vacated slots beyond its new length; use slices.Delete\(h.entries, i, i\+1\)`
+}
+
+func _(s []*entry, i int) []*entry {
+	// Unsafe: removal of several elements
+	copy(s[i:], s[i+2:])
+	s = s[:len(s)-2] // want `slice s of type \*shift.entry has elements shifted out with copy without clearing the vacated slots beyond its new length; use slices.Delete\(s, i, i\+2\)`
+	return s
+}
+
+func _(s []*entry) []*entry {
+	// Unsafe: removal at the front
+	copy(s, s[1:])
+	s = s[:len(s)-1] // want `slice s of type \*shift.entry has elements shifted out with copy without clearing the vacated slots beyond its new length; use slices.Delete\(s, 0, 1\)`
+	return s
+}
+
+func _(s []*entry, i int) []*entry {
+	// Safe: the vacated slot is zeroed
+	copy(s[i:], s[i+1:])
+	s[len(s)-1] = nil
+	s = s[:len(s)-1]
+	return s
+}
+
+func _(s []*entry, i int) []*entry {
+	// Safe: the vacated slots are cleared
+	copy(s[i:], s[i+2:])
+	clear(s[len(s)-2:])
+	s = s[:len(s)-2]
+	return s
+}
+
+func _(s []*entry, i int) []*entry {
+	// Not the idiom: the truncation does not match the shift
+	copy(s[i:], s[i+2:])
+	s = s[:len(s)-1]
+	return s
+}
+
+func (h *history) removeSize(i int) {
+	// Safe: elements hold no references
+	copy(h.sizes[i:], h.sizes[i+1:])
+	h.sizes = h.sizes[:len(h.sizes)-1]
+}
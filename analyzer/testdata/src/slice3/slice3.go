@@ -0,0 +1,76 @@
+package slice3
+
+import "runtime"
+
+type node struct {
+	children []*node
+}
+
+func _() {
+	// Unsafe: full slice expression keeping the capacity
+	s := []*int{new(int)}
+	s = s[:0:cap(s)] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: full slice expression forcing reallocation on the next append;
+	// the elements in the original backing array remain reachable through any other alias of it
+	s := []*int{new(int)}
+	s = s[:0:0] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _(n int) {
+	// Unsafe: full slice expression with a variable capacity
+	s := make([]*int, 4, 8)
+	s = s[:0:n] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: the capacity reads an element, so it cannot be evaluated after clearing and no fix is offered
+	s := []*node{{children: make([]*node, 0, 4)}}
+	s = s[:0:cap(s[0].children)] // want `slice s of type \*slice3.node is resized to zero length without clearing elements \(no fix is suggested: the capacity cap\(s\[0\].children\) may read the elements, which the fix would clear before it is evaluated\)`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: a local cap shadows the built-in and reads the elements, so no fix is offered
+	cap := func(s []*node) int { return len(s[0].children) }
+	s := []*node{{children: make([]*node, 0, 4)}}
+	s = s[:0:cap(s)] // want `slice s of type \*slice3.node is resized to zero length without clearing elements \(no fix is suggested: the capacity cap\(s\) may read the elements`
+	runtime.KeepAlive(s)
+}
+
+func (n *node) reset() {
+	// Unsafe: the fix keeps the zero capacity, so the next append still allocates a new backing array
+	n.children = n.children[:0:0] // want `slice n.children of type \*slice3.node is resized to zero length without clearing elements`
+}
+
+func (n *node) truncate() {
+	// Unsafe: slices.Delete keeps the capacity already, so the fix does not spell it out again
+	n.children = n.children[:0:cap(n.children)] // want `slice n.children of type \*slice3.node is resized to zero length without clearing elements`
+}
+
+func _() {
+	// Unsafe: a capacity of the current length, evaluated before the slice is reassigned
+	s := []*int{new(int), nil}
+	s = s[:0:len(s)] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Safe: clear() directly preceding a full slice expression
+	s := []*int{new(int)}
+	clear(s)
+	s = s[:0:cap(s)]
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Safe: full slice expression over primitive elements
+	s := []int{1}
+	s = s[:0:0]
+	runtime.KeepAlive(s)
+}
@@ -0,0 +1,35 @@
+package sliceutil
+
+// Wipe clears the elements of s.
+func Wipe[T any](s []T) { // want Wipe:`ClearsArgs\(params=\[0\], fields=\[\]\)`
+	clear(s)
+}
+
+// WipeBoth clears the elements of a and b.
+func WipeBoth(a []*int, label string, b []*int) { // want WipeBoth:`ClearsArgs\(params=\[0 2\], fields=\[\]\)`
+	clear(a)
+	for i := range b {
+		b[i] = nil
+	}
+}
+
+// Buffer holds a reusable batch of values.
+type Buffer struct {
+	Items []*int
+	spare []*int
+}
+
+// Wipe clears the items of the buffer, but not its spare slice.
+func (b *Buffer) Wipe() { // want Wipe:`ClearsArgs\(params=\[\], fields=\[Items\]\)`
+	clear(b.Items)
+}
+
+// wipe is not exported, so it exports no fact.
+func wipe(s []*int) {
+	clear(s)
+}
+
+// Truncate only truncates, which clears nothing.
+func Truncate(s []*int) []*int {
+	return s[:0]
+}
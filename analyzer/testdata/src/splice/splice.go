@@ -0,0 +1,43 @@
+package splice
+
+type listener struct {
+	notify func()
+}
+
+type bus struct {
+	listeners []*listener
+	ids       []int
+}
+
+func (b *bus) unsubscribe(i int) {
+	// Unsafe: the last slot keeps a duplicate of the former last element
+	b.listeners = append(b.listeners[:i], b.listeners[i+1:]...) // want `slice b.listeners of type \*splice.listener has elements removed with append without clearing the vacated slots beyond its new length; use slices.Delete\(b.listeners, i, i\+1\)`
+}
+
+func _(s []*listener, i, j int) []*listener {
+	// Unsafe: multi-element removal
+	s = append(s[:i], s[j:]...) // want `slice s of type \*splice.listener has elements removed with append without clearing the vacated slots beyond its new length; use slices.Delete\(s, i, j\)`
+	return s
+}
+
+func _(p *[]*listener, i int) {
+	// Unsafe: through a pointer, with an explicit len bound
+	*p = append((*p)[0:i], (*p)[i+1:len(*p)]...) // want `slice \*p of type \*splice.listener has elements removed with append without clearing the vacated slots beyond its new length; use slices.Delete\(\*p, i, i\+1\)`
+}
+
+func _(s, t []*listener, i int) []*listener {
+	// Not a removal from s: the tail comes from another slice
+	s = append(s[:i], t[i+1:]...)
+	return s
+}
+
+func _(s []*listener, i int) []*listener {
+	// Not a removal in place: the result is assigned to another variable
+	t := append(s[:i], s[i+1:]...)
+	return t
+}
+
+func (b *bus) drop(i int) {
+	// Safe: elements hold no references
+	b.ids = append(b.ids[:i], b.ids[i+1:]...)
+}
@@ -0,0 +1,94 @@
+package swapremove
+
+import "strings"
+
+type conn struct {
+	id int
+}
+
+type registry struct {
+	conns []*conn
+	ports []int
+}
+
+func (r *registry) remove(i int) {
+	// Unsafe: the vacated slot still references the moved element
+	r.conns[i] = r.conns[len(r.conns)-1]
+	r.conns = r.conns[:len(r.conns)-1] // want `slice r.conns of type \*swapremove.conn drops its last element after moving it to r.conns\[i\] without clearing the vacated slot r.conns\[len\(r.conns\)-1\]`
+}
+
+func _(s []*conn, i int) []*conn {
+	// Unsafe: with a temp for the last index
+	last := len(s) - 1
+	s[i] = s[last]
+	s = s[:last] // want `slice s of type \*swapremove.conn drops its last element after moving it to s\[i\] without clearing the vacated slot s\[last\]`
+	return s
+}
+
+func _(s []*conn, i int) []*conn {
+	// Safe: the vacated slot is zeroed before the truncation
+	last := len(s) - 1
+	s[i] = s[last]
+	s[last] = nil
+	s = s[:last]
+	return s
+}
+
+func _(s []*conn, i int) []*conn {
+	// Safe: the vacated slot is zeroed before the truncation
+	s[i] = s[len(s)-1]
+	s[len(s)-1] = nil
+	s = s[:len(s)-1]
+	return s
+}
+
+func _(s, t []*conn, i int) []*conn {
+	// Not the idiom: the temp was computed from a different slice
+	last := len(t) - 1
+	s[i] = s[last]
+	s = s[:last]
+	return s
+}
+
+func (r *registry) removePort(i int) {
+	// Safe: elements hold no references
+	r.ports[i] = r.ports[len(r.ports)-1]
+	r.ports = r.ports[:len(r.ports)-1]
+}
+
+type entry struct {
+	owner *conn
+}
+
+type pair[T any] struct {
+	first, second T
+}
+
+func _(s []entry, i int) []entry {
+	// Unsafe: struct elements are zeroed with a composite literal
+	s[i] = s[len(s)-1]
+	s = s[:len(s)-1] // want `slice s of type swapremove.entry drops its last element after moving it to s\[i\] without clearing the vacated slot s\[len\(s\)-1\]`
+	return s
+}
+
+func _(s []strings.Builder, i int) []strings.Builder {
+	// Unsafe: the composite literal is qualified by the import name
+	s[i] = s[len(s)-1]
+	s = s[:len(s)-1] // want `slice s of type strings.Builder drops its last element after moving it to s\[i\]`
+	return s
+}
+
+func _(s []pair[*conn], i int) []pair[*conn] {
+	// Unsafe: instances keep their type arguments
+	last := len(s) - 1
+	s[i] = s[last]
+	s = s[:last] // want `slice s of type swapremove.pair\[\*swapremove.conn\] drops its last element after moving it to s\[i\]`
+	return s
+}
+
+func _(s [][2]*conn, i int) [][2]*conn {
+	// Unsafe: arrays are zeroed with a composite literal too
+	s[i] = s[len(s)-1]
+	s = s[:len(s)-1] // want `slice s of type \[2\]\*swapremove.conn drops its last element after moving it to s\[i\]`
+	return s
+}
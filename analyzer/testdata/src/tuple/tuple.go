@@ -0,0 +1,59 @@
+package tuple
+
+import "runtime"
+
+func _() {
+	// Unsafe: both parallel buffers are truncated in one tuple assignment
+	keys := []*string{new(string)}
+	vals := []*int{new(int)}
+	keys, vals = keys[:0], vals[:0] // want `slice keys of type \*string is resized to zero length without clearing elements` `slice vals of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(keys)
+	runtime.KeepAlive(vals)
+}
+
+func _() {
+	// Unsafe: only the reference-typed pair of the tuple is reported
+	refs := []*int{new(int)}
+	nums := []int{1}
+	refs, nums = refs[:0], nums[:0] // want `slice refs of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(refs)
+	runtime.KeepAlive(nums)
+}
+
+func _() {
+	// Safe: swapped truncations are not self-truncations
+	a := []*int{new(int)}
+	b := []*int{new(int)}
+	a, b = b[:0], a[:0]
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(b)
+}
+
+func _() {
+	// Unsafe: a buffer and its counter are reset together; only the buffer is rewritten
+	items := []*string{new(string)}
+	count := len(items)
+	items, count = items[:0], 0 // want `slice items of type \*string is resized to zero length without clearing elements`
+	runtime.KeepAlive(items)
+	runtime.KeepAlive(count)
+}
+
+func _() {
+	// Safe: clear() directly preceding the tuple assignment suppresses the cleared pair
+	items := []*string{new(string)}
+	count := len(items)
+	clear(items)
+	items, count = items[:0], 0
+	runtime.KeepAlive(items)
+	runtime.KeepAlive(count)
+}
+
+func _() {
+	// Unsafe: clear() of one pair does not suppress the other
+	keys := []*string{new(string)}
+	vals := []*int{new(int)}
+	clear(keys)
+	keys, vals = keys[:0], vals[:0] // want `slice vals of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(keys)
+	runtime.KeepAlive(vals)
+}
@@ -0,0 +1,8 @@
+package withtests_test
+
+import "withtests"
+
+func resetExternal(s []*withtests.Fixture) []*withtests.Fixture {
+	s = s[:0] // want `slice s of type \*withtests.Fixture is resized to zero length without clearing elements`
+	return s
+}
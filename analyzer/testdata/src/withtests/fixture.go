@@ -0,0 +1,8 @@
+package withtests
+
+type Fixture struct{ name string }
+
+func reset(s []*Fixture) []*Fixture {
+	s = s[:0] // want `slice s of type \*withtests.Fixture is resized to zero length without clearing elements`
+	return s
+}
@@ -0,0 +1,6 @@
+package withtests
+
+func resetFixtures(s []*Fixture) []*Fixture {
+	s = s[:0] // want `slice s of type \*withtests.Fixture is resized to zero length without clearing elements`
+	return s
+}
@@ -0,0 +1,8 @@
+package withtestsskipped
+
+type Fixture struct{ name string }
+
+func reset(s []*Fixture) []*Fixture {
+	s = s[:0] // want `slice s of type \*withtestsskipped.Fixture is resized to zero length without clearing elements`
+	return s
+}
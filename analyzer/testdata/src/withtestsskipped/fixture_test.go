@@ -0,0 +1,6 @@
+package withtestsskipped
+
+func resetFixtures(s []*Fixture) []*Fixture {
+	s = s[:0]
+	return s
+}
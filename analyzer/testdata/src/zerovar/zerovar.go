@@ -0,0 +1,93 @@
+package zerovar
+
+import "runtime"
+
+const zero = 0
+
+func _() {
+	// Unsafe: the high index is a local that is only ever zero
+	s := []*int{new(int)}
+	n := 0
+	s = s[:n] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: the high index is a local declared with its zero value
+	s := []*int{new(int)}
+	var n int
+	s = s[:n] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: the high index is a local initialized to a constant zero
+	s := []*int{new(int)}
+	var n = zero
+	s = s[:n] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Unsafe: the capacity of a full slice expression is kept along with the local
+	s := []*int{new(int)}
+	n := 0
+	s = s[:n:len(s)] // want `slice s of type \*int is resized to zero length without clearing elements`
+	runtime.KeepAlive(s)
+}
+
+func _(m int) {
+	// Safe: the local is reassigned before the truncation
+	s := []*int{new(int)}
+	n := 0
+	if m > 0 {
+		n = m
+	}
+	s = s[:n]
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Safe: the local is incremented
+	s := []*int{new(int), new(int)}
+	n := 0
+	n++
+	s = s[:n]
+	runtime.KeepAlive(s)
+}
+
+func _(set func(*int)) {
+	// Safe: the local's address is taken
+	s := []*int{new(int)}
+	n := 0
+	set(&n)
+	s = s[:n]
+	runtime.KeepAlive(s)
+}
+
+func _() {
+	// Safe: the local is captured by a closure
+	s := []*int{new(int)}
+	n := 0
+	grow := func() { n = len(s) }
+	grow()
+	s = s[:n]
+	runtime.KeepAlive(s)
+}
+
+func _(n int) {
+	// Safe: a parameter's value is unknown
+	s := []*int{new(int)}
+	s = s[:n]
+	runtime.KeepAlive(s)
+}
+
+func _(m int) {
+	// Safe: the local is redefined by a later short variable declaration
+	s := []*int{new(int)}
+	n := 0
+	ok, n := true, m
+	runtime.KeepAlive(ok)
+	s = s[:n]
+	runtime.KeepAlive(s)
+}
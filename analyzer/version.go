@@ -0,0 +1,40 @@
+package clearslice
+
+import (
+	"go/token"
+	"go/version"
+)
+
+// goVersionAtLeast reports whether the file containing pos is compiled for at least Go version v, such as "go1.21".
+// An unknown version imposes no restriction, as in the type checker.
+func (c *checker) goVersionAtLeast(pos token.Pos, v string) bool {
+	fileVersion := c.goVersion(pos)
+	return fileVersion == "" || version.Compare(fileVersion, v) >= 0
+}
+
+// deleteClears reports whether slices.Delete zeroes the elements it removes from the slice in the file containing pos.
+func (c *checker) deleteClears(pos token.Pos) bool {
+	return deleteZeroesTail(c.goVersion(pos))
+}
+
+// deleteZeroesTail reports whether slices.Delete zeroes the vacated elements between the new and the old length
+// when compiling for Go version v, which it does from Go 1.22. A module declaring go 1.21 may be built with
+// a Go 1.21 toolchain, whose slices.Delete leaves them in place. An unknown version is taken to be recent.
+func deleteZeroesTail(v string) bool {
+	return v == "" || version.Compare(v, "go1.22") >= 0
+}
+
+// deleteKeepsTailNote is appended to messages recommending slices.Delete in files compiled for a Go version before 1.22.
+const deleteKeepsTailNote = " (slices.Delete clears the vacated slots only from Go 1.22; clear them explicitly before that)"
+
+// goVersion returns the Go version the file containing pos is compiled for, such as "go1.20", or "" if unknown.
+// The file version (set by a //go:build line) takes precedence over the package version, which comes from go.mod.
+func (c *checker) goVersion(pos token.Pos) string {
+	fileVersion := c.pass.Pkg.GoVersion()
+	if file := c.fileOf(pos); file != nil {
+		if fv := c.pass.TypesInfo.FileVersions[file]; fv != "" {
+			fileVersion = fv
+		}
+	}
+	return fileVersion
+}
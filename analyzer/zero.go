@@ -0,0 +1,186 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// findZeroVars returns the local variables whose only definition is the constant zero (or the zero value of
+// `var n int`) and that are never written, incremented, addressed or captured by a closure afterwards.
+// Such a variable holds zero at every use, so `s = s[:n]` truncates s to zero length just like `s = s[:0]`.
+// The inference is deliberately conservative: any other kind of definition or mutation disables it.
+func findZeroVars(pass *analysis.Pass, inspect *inspector.Inspector) map[*types.Var]bool {
+	info := pass.TypesInfo
+
+	candidates := make(map[*types.Var]bool)
+	disqualified := make(map[*types.Var]bool)
+
+	// varOf resolves an identifier to the local variable it defines or uses.
+	varOf := func(expr ast.Expr) *types.Var {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		v, _ := info.ObjectOf(ident).(*types.Var)
+		return v
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.IncDecStmt)(nil),
+		(*ast.UnaryExpr)(nil),
+		(*ast.RangeStmt)(nil),
+		(*ast.FuncLit)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for j, lhs := range n.Lhs {
+				v := varOf(lhs)
+				if v == nil {
+					continue
+				}
+				// A definition by `n := 0` makes v a candidate; every other assignment disqualifies it.
+				if n.Tok == token.DEFINE && info.Defs[lhs.(*ast.Ident)] == v {
+					if len(n.Lhs) == len(n.Rhs) && isZeroConst(info, n.Rhs[j]) {
+						candidates[v] = true
+					}
+					continue
+				}
+				disqualified[v] = true
+			}
+		case *ast.ValueSpec:
+			// Package-level variables may be written from anywhere, so only local declarations qualify.
+			for j, name := range n.Names {
+				v, ok := info.Defs[name].(*types.Var)
+				if !ok || v.Parent() == pass.Pkg.Scope() {
+					continue
+				}
+				if len(n.Values) == 0 || (len(n.Values) == len(n.Names) && isZeroConst(info, n.Values[j])) {
+					candidates[v] = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if v := varOf(n.X); v != nil {
+				disqualified[v] = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				if v := varOf(n.X); v != nil {
+					disqualified[v] = true
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN {
+				for _, expr := range []ast.Expr{n.Key, n.Value} {
+					if v := varOf(expr); v != nil {
+						disqualified[v] = true
+					}
+				}
+			}
+		case *ast.FuncLit:
+			// A closure may run at any time, so any variable it captures from the outside is disqualified.
+			ast.Inspect(n.Body, func(n2 ast.Node) bool {
+				if ident, ok := n2.(*ast.Ident); ok {
+					if v, ok := info.Uses[ident].(*types.Var); ok && (v.Pos() < n.Pos() || v.Pos() >= n.End()) {
+						disqualified[v] = true
+					}
+				}
+				return true
+			})
+		}
+	})
+
+	zeroVars := make(map[*types.Var]bool)
+	for v := range candidates {
+		if !disqualified[v] {
+			zeroVars[v] = true
+		}
+	}
+	return zeroVars
+}
+
+// findZeroBuffers returns the unexported package-level and the local slice variables made with a constant length,
+// like `var zeroConns = make([]*Conn, 1024)`, and never written afterwards, mapped to that length.
+// Such a slice holds only zero values, so copy(s, zeroConns) zeroes the prefix of s it covers.
+// Every use other than as the source of copy or the argument of len or cap disqualifies the variable.
+func findZeroBuffers(pass *analysis.Pass, inspect *inspector.Inspector) map[*types.Var]int64 {
+	info := pass.TypesInfo
+
+	lengths := make(map[*types.Var]int64)
+	define := func(name *ast.Ident, value ast.Expr) {
+		v, ok := info.Defs[name].(*types.Var)
+		if !ok || (v.Parent() == pass.Pkg.Scope() && v.Exported()) {
+			return
+		}
+		call, ok := ast.Unparen(value).(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 || !isBuiltinCall(info, call, "make") {
+			return
+		}
+		if _, isSlice := v.Type().Underlying().(*types.Slice); !isSlice {
+			return
+		}
+		if n, ok := constInt(info, call.Args[1]); ok && n > 0 {
+			lengths[v] = n
+		}
+	}
+	reads := make(map[*types.Var]int)
+	read := func(expr ast.Expr) {
+		if ident, ok := ast.Unparen(expr).(*ast.Ident); ok {
+			if v, ok := info.Uses[ident].(*types.Var); ok {
+				reads[v]++
+			}
+		}
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE && len(n.Lhs) == len(n.Rhs) {
+				for j, lhs := range n.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						define(ident, n.Rhs[j])
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Values) == len(n.Names) {
+				for j, name := range n.Names {
+					define(name, n.Values[j])
+				}
+			}
+		case *ast.CallExpr:
+			switch {
+			case isBuiltinCall(info, n, "copy") && len(n.Args) == 2:
+				read(n.Args[1])
+			case (isBuiltinCall(info, n, "len") || isBuiltinCall(info, n, "cap")) && len(n.Args) == 1:
+				read(n.Args[0])
+			}
+		}
+	})
+
+	uses := make(map[*types.Var]int)
+	for _, obj := range info.Uses {
+		if v, ok := obj.(*types.Var); ok {
+			if _, ok := lengths[v]; ok {
+				uses[v]++
+			}
+		}
+	}
+	for v := range lengths {
+		if uses[v] != reads[v] {
+			delete(lengths, v)
+		}
+	}
+	return lengths
+}
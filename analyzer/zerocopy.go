@@ -0,0 +1,95 @@
+package clearslice
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// isZeroCopyOf reports whether stmt zeroes target by copying from a zero buffer (see findZeroBuffers) of the same
+// slice type, the idiom that predates the clear built-in:
+//
+//	var zeroConns = make([]*Conn, maxConns)
+//
+//	copy(p.conns, zeroConns)
+//
+// copy only zeroes as many elements as the buffer holds. When the length of target is bounded by constants
+// (see maxConstLen), the buffer must be at least that long; otherwise it is assumed to be long enough.
+func (c *checker) isZeroCopyOf(stmt ast.Stmt, target ast.Expr) bool {
+	info := c.pass.TypesInfo
+	dst, length, ok := c.zeroCopy(stmt)
+	if !ok {
+		return false
+	}
+	if full := fullLengthOf(info, dst); full != nil {
+		dst = full
+	} else if full := fullCapacityOf(info, dst); full != nil {
+		dst = full
+	}
+	if !c.sameSlice(target, dst) {
+		return false
+	}
+	if maxLen, known := c.maxConstLen(target); known && maxLen > length {
+		return false
+	}
+	return true
+}
+
+// zeroCopy returns the destination of stmt and the length of its source if stmt is a statement copy(dst, zero)
+// from a zero buffer whose type is identical to that of dst.
+func (c *checker) zeroCopy(stmt ast.Stmt) (ast.Expr, int64, bool) {
+	info := c.pass.TypesInfo
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil, 0, false
+	}
+	call, ok := ast.Unparen(exprStmt.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || !isBuiltinCall(info, call, "copy") {
+		return nil, 0, false
+	}
+	src, ok := ast.Unparen(call.Args[1]).(*ast.Ident)
+	if !ok {
+		return nil, 0, false
+	}
+	v, ok := info.Uses[src].(*types.Var)
+	if !ok {
+		return nil, 0, false
+	}
+	length, ok := c.zeroBuffers[v]
+	if !ok || !types.Identical(v.Type(), info.TypeOf(call.Args[0])) {
+		return nil, 0, false
+	}
+	return call.Args[0], length, true
+}
+
+// checkZeroCopies reports copies from a zero buffer, `copy(s, zeroConns)`, which the clear built-in does without
+// the buffer since Go 1.21. Files compiled for an older Go version are skipped. The fix replaces the copy with
+// clear(s), which also zeroes any elements beyond the length of the buffer, as the idiom intends; since the copy
+// left them as they were, the fix is classified for verification.
+func (c *checker) checkZeroCopies(inspect *inspector.Inspector) {
+	for cur := range inspect.Root().Preorder((*ast.ExprStmt)(nil)) {
+		stmt := cur.Node().(*ast.ExprStmt)
+		dst, _, ok := c.zeroCopy(stmt)
+		if !ok || !c.goVersionAtLeast(stmt.Pos(), "go1.21") {
+			continue
+		}
+		name := c.sourceOf(dst)
+		src := stmt.X.(*ast.CallExpr).Args[1]
+		c.pass.Report(analysis.Diagnostic{
+			Pos:      stmt.Pos(),
+			End:      stmt.End(),
+			Category: categoryModernizeClear,
+			Message:  "copy from zero buffer " + c.sourceOf(src) + " into " + name + " can be replaced with clear(" + name + ")",
+			SuggestedFixes: []analysis.SuggestedFix{
+				classify(fixVerify, analysis.SuggestedFix{
+					Message: "Replace the copy with clear(" + name + ").",
+					TextEdits: []analysis.TextEdit{
+						{Pos: stmt.Pos(), End: stmt.End(), NewText: []byte(keepExprComments(c.pass, stmt.Pos(), stmt.End(), "clear("+name+")"))},
+					},
+				}),
+			},
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package clearslice
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// zeroLiteral returns the spelling of the zero value of t in the file at pos, along with the edits importing the
+// packages it refers to that the file does not import yet (see importName):
+//
+//   - nil for pointers, interfaces, slices, maps, channels and functions,
+//   - "", false or 0 for strings, booleans and numbers, also of named types,
+//   - an empty composite literal for structs and arrays, like entry{}, strings.Builder{} or pair[*conn]{},
+//   - *new(T) for type parameters.
+//
+// Types from other packages, also as type arguments, are qualified by the name the file imports them under. It
+// returns false if t cannot be spelled in the package being analyzed, as for unexported types of other packages.
+func (c *checker) zeroLiteral(pos token.Pos, t types.Type) (string, []analysis.TextEdit, bool) {
+	if isNillable(t) {
+		return "nil", nil, true
+	}
+	if param, isTypeParam := types.Unalias(t).(*types.TypeParam); isTypeParam {
+		return "*new(" + param.Obj().Name() + ")", nil, true
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return `""`, nil, true
+		case u.Info()&types.IsBoolean != 0:
+			return "false", nil, true
+		case u.Info()&types.IsNumeric != 0:
+			return "0", nil, true
+		case u.Kind() == types.UnsafePointer:
+			return "nil", nil, true
+		}
+	case *types.Struct, *types.Array:
+		if !c.spellable(t) {
+			return "", nil, false
+		}
+		if name, edits, ok := c.typeName(pos, t); ok {
+			return name + "{}", edits, true
+		}
+	}
+	return "", nil, false
+}
+
+// typeName returns the spelling of t in the file at pos, qualifying the types of other packages by the name the
+// file imports them under, along with the edits adding the imports the file lacks. It returns false if one of them
+// cannot be imported by the file: an internal package of another tree, or one whose name is not the last element
+// of its path, which importName would get wrong.
+func (c *checker) typeName(pos token.Pos, t types.Type) (string, []analysis.TextEdit, bool) {
+	var edits []analysis.TextEdit
+	ok := true
+	name := types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == c.pass.Pkg {
+			return ""
+		}
+		if name, imported := c.importedName(pos, pkg.Path()); imported {
+			return name
+		}
+		if pkg.Name() != pkg.Path()[strings.LastIndex(pkg.Path(), "/")+1:] || !canImport(c.pass.Pkg.Path(), pkg.Path()) {
+			ok = false
+			return pkg.Name()
+		}
+		name, imports := c.importName(pos, pkg.Path())
+		for _, edit := range imports {
+			if !slices.ContainsFunc(edits, func(e analysis.TextEdit) bool {
+				return e.Pos == edit.Pos && e.End == edit.End && string(e.NewText) == string(edit.NewText)
+			}) {
+				edits = append(edits, edit)
+			}
+		}
+		return name
+	})
+	return name, edits, ok
+}
+
+// canImport reports whether the package from may import the package path, which it cannot if path is internal
+// to a tree that does not contain from, like the internal packages of the standard library.
+func canImport(from, path string) bool {
+	i := strings.LastIndex("/"+path+"/", "/internal/")
+	if i < 0 {
+		return true
+	}
+	if i == 0 {
+		return false
+	}
+	parent := path[:i-1]
+	return from == parent || strings.HasPrefix(from, parent+"/")
+}
+
+// spellable reports whether t can be written in the package being analyzed: the named types it refers to, also as
+// type arguments, are declared in the package or exported, and so are the fields of its struct types.
+func (c *checker) spellable(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Alias:
+		return c.spellable(types.Unalias(t))
+	case *types.Named:
+		if pkg := t.Obj().Pkg(); pkg != nil && pkg != c.pass.Pkg && !t.Obj().Exported() {
+			return false
+		}
+		for i := range t.TypeArgs().Len() {
+			if !c.spellable(t.TypeArgs().At(i)) {
+				return false
+			}
+		}
+		return true
+	case *types.Pointer:
+		return c.spellable(t.Elem())
+	case *types.Slice:
+		return c.spellable(t.Elem())
+	case *types.Array:
+		return c.spellable(t.Elem())
+	case *types.Chan:
+		return c.spellable(t.Elem())
+	case *types.Map:
+		return c.spellable(t.Key()) && c.spellable(t.Elem())
+	case *types.Struct:
+		for i := range t.NumFields() {
+			field := t.Field(i)
+			if (field.Pkg() != c.pass.Pkg && !field.Exported()) || !c.spellable(field.Type()) {
+				return false
+			}
+		}
+		return true
+	case *types.Signature:
+		return c.spellable(t.Params()) && c.spellable(t.Results())
+	case *types.Tuple:
+		for i := range t.Len() {
+			if !c.spellable(t.At(i).Type()) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
@@ -2,9 +2,9 @@ package main
 
 import (
 	clearslice "github.com/zcross/clearslice/analyzer"
-	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/analysis/multichecker"
 )
 
 func main() {
-	singlechecker.Main(clearslice.NewAnalyzer())
+	multichecker.Main(clearslice.NewAnalyzer(), clearslice.NewMapClearAnalyzer())
 }